@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// checksumAlgorithm describes one hash algorithm this scanner can recompute
+// when verifying a checksum manifest entry.
+type checksumAlgorithm struct {
+	hexLen  int
+	newHash func() hash.Hash
+}
+
+// checksumAlgorithms is the registry of algorithms this scanner recomputes,
+// keyed by the canonical name used in BSD-style lines and manifest filenames.
+var checksumAlgorithms = map[string]checksumAlgorithm{
+	"MD5":    {32, md5.New},
+	"SHA1":   {40, sha1.New},
+	"SHA224": {56, sha256.New224},
+	"SHA256": {64, sha256.New},
+	"SHA384": {96, sha512.New384},
+	"SHA512": {128, sha512.New},
+}
+
+// checksumAlgorithmsByHexLen maps a hex digest's length back to the
+// algorithm name that produces it, for GNU-style lines that carry no
+// explicit algorithm label.
+var checksumAlgorithmsByHexLen = map[int]string{
+	32:  "MD5",
+	40:  "SHA1",
+	56:  "SHA224",
+	64:  "SHA256",
+	96:  "SHA384",
+	128: "SHA512",
+}
+
+// weakChecksumAlgorithms lists algorithms no longer fit for integrity
+// verification in a supply-chain context.
+var weakChecksumAlgorithms = map[string]bool{
+	"MD5":  true,
+	"SHA1": true,
+}
+
+// manifestAlgorithmByName maps checksum manifest filenames that pin their
+// own algorithm (SHA512SUMS, MD5SUMS) to that algorithm.
+var manifestAlgorithmByName = map[string]string{
+	"SHA256SUMS": "SHA256",
+	"SHA512SUMS": "SHA512",
+	"SHA1SUMS":   "SHA1",
+	"MD5SUMS":    "MD5",
+}
+
+// manifestAlgorithmByExtension maps checksum file extensions that pin their
+// own algorithm (*.md5sum, *.sha256) to that algorithm.
+var manifestAlgorithmByExtension = map[string]string{
+	".sha256":    "SHA256",
+	".sha256sum": "SHA256",
+	".sha512":    "SHA512",
+	".sha512sum": "SHA512",
+	".md5":       "MD5",
+	".md5sum":    "MD5",
+}
+
+// reChecksumLine matches GNU coreutils style lines (also produced by
+// `openssl dgst -r`): a hex digest, whitespace, an optional "*" binary-mode
+// marker, then a filename.
+var reChecksumLine = regexp.MustCompile(`^([a-fA-F0-9]{32,128})\s+(.+)$`)
+
+// reBSDChecksumLine matches BSD-style lines, e.g. "SHA256 (release.tar.gz) = <hex>".
+var reBSDChecksumLine = regexp.MustCompile(`^([A-Za-z0-9]+)\s*\(([^)]+)\)\s*=\s*([a-fA-F0-9]+)$`)
+
+// isChecksumManifest reports whether name is a bulk checksum manifest or a
+// single-artifact companion checksum file this scanner should read line by
+// line.
+func isChecksumManifest(name string) bool {
+	if checksumFileNames[name] || manifestAlgorithmByName[name] != "" {
+		return true
+	}
+	lower := strings.ToLower(name)
+	for ext := range checksumExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// pinnedChecksumAlgorithm reports the algorithm a checksum manifest's own
+// filename commits it to, if any, so lines that disagree can be flagged
+// rather than silently verified against the wrong algorithm.
+func pinnedChecksumAlgorithm(manifestName string) (string, bool) {
+	if algorithm, ok := manifestAlgorithmByName[manifestName]; ok {
+		return algorithm, true
+	}
+	lower := strings.ToLower(manifestName)
+	for ext, algorithm := range manifestAlgorithmByExtension {
+		if strings.HasSuffix(lower, ext) {
+			return algorithm, true
+		}
+	}
+	return "", false
+}
+
+// parseChecksumLine parses a single checksum manifest line in either
+// BSD or GNU/OpenSSL format, returning the declared digest, the file it
+// covers, and the algorithm that produced it.
+func parseChecksumLine(line string) (declaredHash, referencedFile, algorithm string, ok bool) {
+	if m := reBSDChecksumLine.FindStringSubmatch(line); m != nil {
+		algorithm = strings.ToUpper(m[1])
+		declaredHash = strings.ToLower(m[3])
+		spec, known := checksumAlgorithms[algorithm]
+		if !known || len(declaredHash) != spec.hexLen {
+			return "", "", "", false
+		}
+		return declaredHash, strings.TrimSpace(m[2]), algorithm, true
+	}
+
+	m := reChecksumLine.FindStringSubmatch(line)
+	if m == nil {
+		return "", "", "", false
+	}
+
+	declaredHash = strings.ToLower(m[1])
+	referencedFile = strings.TrimPrefix(strings.TrimSpace(m[2]), "*")
+	algorithm, known := checksumAlgorithmsByHexLen[len(declaredHash)]
+	if !known {
+		return "", "", "", false
+	}
+	return declaredHash, referencedFile, algorithm, true
+}
+
+// checkChecksumMismatches reads a checksum manifest file and verifies that
+// referenced files match their declared checksums, across whichever
+// algorithm each line declares or its hex length implies. When the
+// manifest's own filename pins an algorithm, lines declaring a different
+// one are flagged as ARTINT-008 instead of being verified. A manifest
+// whose only hashes are MD5 or SHA-1 is flagged as ARTINT-009.
+func checkChecksumMismatches(resp *sdk.ResponseBuilder, checksumFilePath, dir string) {
+	f, err := os.Open(checksumFilePath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	manifestName := filepath.Base(checksumFilePath)
+	pinnedAlgorithm, pinned := pinnedChecksumAlgorithm(manifestName)
+	seenAlgorithms := make(map[string]bool)
+
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		declaredHash, referencedFile, algorithm, ok := parseChecksumLine(line)
+		if !ok {
+			continue
+		}
+
+		if pinned && algorithm != pinnedAlgorithm {
+			resp.Finding(
+				"ARTINT-008",
+				sdk.SeverityMedium,
+				sdk.ConfidenceHigh,
+				fmt.Sprintf("%s declares a %s hash but the manifest filename implies %s", referencedFile, algorithm, pinnedAlgorithm),
+			).
+				At(checksumFilePath, lineNum, lineNum).
+				WithMetadata("file", referencedFile).
+				WithMetadata("algorithm", algorithm).
+				WithMetadata("expected_algorithm", pinnedAlgorithm).
+				WithMetadata("type", "manifest_algorithm_mismatch").
+				Done()
+			continue
+		}
+
+		seenAlgorithms[algorithm] = true
+
+		spec := checksumAlgorithms[algorithm]
+		targetPath := filepath.Join(dir, referencedFile)
+		data, err := os.ReadFile(targetPath)
+		if err != nil {
+			// File not found -- not a mismatch, just missing.
+			continue
+		}
+
+		h := spec.newHash()
+		h.Write(data)
+		actualHash := hex.EncodeToString(h.Sum(nil))
+		if actualHash != declaredHash {
+			resp.Finding(
+				"ARTINT-003",
+				sdk.SeverityCritical,
+				sdk.ConfidenceHigh,
+				fmt.Sprintf("Checksum mismatch for %s: declared=%s actual=%s", referencedFile, declaredHash[:16]+"...", actualHash[:16]+"..."),
+			).
+				At(checksumFilePath, lineNum, lineNum).
+				WithMetadata("file", referencedFile).
+				WithMetadata("algorithm", algorithm).
+				WithMetadata("type", "checksum_mismatch").
+				Done()
+		}
+	}
+
+	emitWeakAlgorithmWarning(resp, checksumFilePath, manifestName, seenAlgorithms)
+}
+
+// emitWeakAlgorithmWarning reports ARTINT-009 when every algorithm a
+// checksum manifest uses is MD5 or SHA-1, neither of which is collision
+// resistant enough to trust for supply-chain integrity.
+func emitWeakAlgorithmWarning(resp *sdk.ResponseBuilder, checksumFilePath, manifestName string, seenAlgorithms map[string]bool) {
+	if len(seenAlgorithms) == 0 {
+		return
+	}
+
+	var weak []string
+	for algorithm := range seenAlgorithms {
+		if !weakChecksumAlgorithms[algorithm] {
+			return
+		}
+		weak = append(weak, algorithm)
+	}
+	sort.Strings(weak)
+
+	resp.Finding(
+		"ARTINT-009",
+		sdk.SeverityLow,
+		sdk.ConfidenceHigh,
+		fmt.Sprintf("%s uses only weak hash algorithms (%s), unfit for supply-chain integrity", manifestName, strings.Join(weak, ", ")),
+	).
+		At(checksumFilePath, 0, 0).
+		WithMetadata("algorithm", strings.Join(weak, ",")).
+		WithMetadata("type", "weak_checksum_algorithm").
+		Done()
+}
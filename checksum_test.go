@@ -0,0 +1,120 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+func TestParseChecksumLine(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		wantHash      string
+		wantFile      string
+		wantAlgorithm string
+		wantOK        bool
+	}{
+		{"gnu sha256", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  release.tar.gz", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", "release.tar.gz", "SHA256", true},
+		{"gnu binary mode", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855 *release.tar.gz", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", "release.tar.gz", "SHA256", true},
+		{"gnu md5", "d41d8cd98f00b204e9800998ecf8427e  empty.bin", "d41d8cd98f00b204e9800998ecf8427e", "empty.bin", "MD5", true},
+		{"bsd style", "SHA256 (release.tar.gz) = e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", "release.tar.gz", "SHA256", true},
+		{"bsd style length mismatch", "SHA256 (release.tar.gz) = d41d8cd98f00b204e9800998ecf8427e", "", "", "", false},
+		{"unknown hex length", "abcd  release.tar.gz", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotHash, gotFile, gotAlgorithm, ok := parseChecksumLine(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("parseChecksumLine(%q) ok = %v, want %v", tt.line, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if gotHash != tt.wantHash || gotFile != tt.wantFile || gotAlgorithm != tt.wantAlgorithm {
+				t.Errorf("parseChecksumLine(%q) = (%q, %q, %q), want (%q, %q, %q)",
+					tt.line, gotHash, gotFile, gotAlgorithm, tt.wantHash, tt.wantFile, tt.wantAlgorithm)
+			}
+		})
+	}
+}
+
+func TestPinnedChecksumAlgorithm(t *testing.T) {
+	tests := []struct {
+		name          string
+		wantAlgorithm string
+		wantPinned    bool
+	}{
+		{"SHA512SUMS", "SHA512", true},
+		{"MD5SUMS", "MD5", true},
+		{"release.tar.gz.md5sum", "MD5", true},
+		{"release.tar.gz.sha256", "SHA256", true},
+		{"CHECKSUMS", "", false},
+		{"checksums.txt", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			algorithm, pinned := pinnedChecksumAlgorithm(tt.name)
+			if pinned != tt.wantPinned || algorithm != tt.wantAlgorithm {
+				t.Errorf("pinnedChecksumAlgorithm(%q) = (%q, %v), want (%q, %v)", tt.name, algorithm, pinned, tt.wantAlgorithm, tt.wantPinned)
+			}
+		})
+	}
+}
+
+func TestCheckChecksumMismatchesPinnedAlgorithmMismatch(t *testing.T) {
+	resp := sdk.NewResponse()
+	dir := filepath.Join("testdata", "checksum-pinned-mismatch")
+
+	checkChecksumMismatches(resp, filepath.Join(dir, "SHA512SUMS"), dir)
+
+	found := findByRule(resp.Build().GetFindings(), "ARTINT-008")
+	if len(found) == 0 {
+		t.Fatal("expected ARTINT-008 for a SHA256-length hash inside a SHA512SUMS manifest")
+	}
+	if found[0].GetMetadata()["file"] != "bad.bin" {
+		t.Errorf("expected mismatch reported against bad.bin, got %q", found[0].GetMetadata()["file"])
+	}
+}
+
+func TestCheckChecksumMismatchesBSDFormat(t *testing.T) {
+	resp := sdk.NewResponse()
+	dir := filepath.Join("testdata", "checksum-bsd-format")
+
+	checkChecksumMismatches(resp, filepath.Join(dir, "CHECKSUMS"), dir)
+
+	found := findByRule(resp.Build().GetFindings(), "ARTINT-003")
+	hasApp2 := false
+	for _, f := range found {
+		if f.GetMetadata()["file"] == "app2.bin" {
+			hasApp2 = true
+			if f.GetMetadata()["algorithm"] != "SHA256" {
+				t.Errorf("expected algorithm metadata SHA256, got %q", f.GetMetadata()["algorithm"])
+			}
+		}
+		if f.GetMetadata()["file"] == "app.bin" {
+			t.Error("app.bin's BSD-format checksum is correct and should not be flagged")
+		}
+	}
+	if !hasApp2 {
+		t.Error("expected app2.bin to be flagged for its checksum mismatch")
+	}
+}
+
+func TestCheckChecksumMismatchesWeakAlgorithm(t *testing.T) {
+	resp := sdk.NewResponse()
+	dir := filepath.Join("testdata", "checksum-weak-algorithm")
+
+	checkChecksumMismatches(resp, filepath.Join(dir, "MD5SUMS"), dir)
+
+	found := findByRule(resp.Build().GetFindings(), "ARTINT-009")
+	if len(found) != 1 {
+		t.Fatalf("expected exactly one ARTINT-009 finding, got %d", len(found))
+	}
+	if found[0].GetMetadata()["algorithm"] != "MD5" {
+		t.Errorf("expected algorithm metadata MD5, got %q", found[0].GetMetadata()["algorithm"])
+	}
+}
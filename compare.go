@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+	"github.com/nox-hq/nox/sdk"
+
+	"github.com/nox-hq/nox-plugin-artifact-integrity/internal/archdiff"
+)
+
+// handleCompare implements the `compare` tool: a diffoscope-style
+// reproducible-build comparison between a baseline and a candidate
+// directory tree.
+func handleCompare(ctx context.Context, req sdk.ToolRequest) (*pluginv1.InvokeToolResponse, error) {
+	baselineRoot, _ := req.Input["baseline_root"].(string)
+	candidateRoot, _ := req.Input["candidate_root"].(string)
+	glob, _ := req.Input["artifact_glob"].(string)
+
+	resp := sdk.NewResponse()
+	if baselineRoot == "" || candidateRoot == "" {
+		return resp.Build(), nil
+	}
+
+	baselineFiles, err := collectRelativeFiles(baselineRoot, glob)
+	if err != nil {
+		return nil, fmt.Errorf("walking baseline_root: %w", err)
+	}
+	candidateFiles, err := collectRelativeFiles(candidateRoot, glob)
+	if err != nil {
+		return nil, fmt.Errorf("walking candidate_root: %w", err)
+	}
+
+	for relPath := range baselineFiles {
+		if ctx.Err() != nil {
+			break
+		}
+		if !candidateFiles[relPath] {
+			continue // no candidate counterpart to pair against
+		}
+		comparePair(resp, baselineRoot, candidateRoot, relPath)
+	}
+
+	return resp.Build(), nil
+}
+
+// collectRelativeFiles walks root and returns the set of regular files
+// found, keyed by path relative to root. When glob is non-empty, only
+// files whose base name matches it are included.
+func collectRelativeFiles(root, glob string) (map[string]bool, error) {
+	files := make(map[string]bool)
+
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if skippedDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if glob != "" {
+			if ok, _ := filepath.Match(glob, d.Name()); !ok {
+				return nil
+			}
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		files[rel] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// comparePair compares one relative path present in both trees: first by
+// raw SHA-256, then, for recognized archive formats, by normalized member
+// content so packaging nondeterminism (mtimes, uids, entry order) doesn't
+// mask -- or get mistaken for -- a real content difference. A non-identical
+// pair in a format archdiff doesn't know how to unpack is still reported,
+// just without the member-level detail.
+func comparePair(resp *sdk.ResponseBuilder, baselineRoot, candidateRoot, relPath string) {
+	baselinePath := filepath.Join(baselineRoot, relPath)
+	candidatePath := filepath.Join(candidateRoot, relPath)
+
+	baselineData, err := os.ReadFile(baselinePath)
+	if err != nil {
+		return
+	}
+	candidateData, err := os.ReadFile(candidatePath)
+	if err != nil {
+		return
+	}
+
+	if sha256Hex(baselineData) == sha256Hex(candidateData) {
+		resp.Finding(
+			"ARTINT-020",
+			sdk.SeverityLow,
+			sdk.ConfidenceHigh,
+			fmt.Sprintf("%s is byte-identical between baseline and candidate", relPath),
+		).
+			At(candidatePath, 0, 0).
+			WithMetadata("artifact", relPath).
+			WithMetadata("type", "identical").
+			Done()
+		return
+	}
+
+	if archdiff.DetectFormat(relPath) == archdiff.FormatUnknown {
+		resp.Finding(
+			"ARTINT-022",
+			sdk.SeverityMedium,
+			sdk.ConfidenceHigh,
+			fmt.Sprintf("%s differs from baseline", relPath),
+		).
+			At(candidatePath, 0, 0).
+			WithMetadata("artifact", relPath).
+			WithMetadata("type", "content_diff").
+			Done()
+		return
+	}
+
+	compareArchivePair(resp, baselinePath, candidatePath, relPath)
+}
+
+// compareArchivePair parses both sides of an archive-format pair and
+// reports ARTINT-021 with a structured member-level diff.
+func compareArchivePair(resp *sdk.ResponseBuilder, baselinePath, candidatePath, relPath string) {
+	baselineMembers, err := archdiff.ReadMembers(baselinePath)
+	if err != nil {
+		return
+	}
+	candidateMembers, err := archdiff.ReadMembers(candidatePath)
+	if err != nil {
+		return
+	}
+
+	diff := archdiff.CompareMembers(baselineMembers, candidateMembers)
+	diffJSON, err := json.Marshal(diff)
+	if err != nil {
+		return
+	}
+
+	resp.Finding(
+		"ARTINT-021",
+		sdk.SeverityMedium,
+		sdk.ConfidenceHigh,
+		fmt.Sprintf("%s differs from baseline after unpacking (%d added, %d removed, %d changed)", relPath, len(diff.Added), len(diff.Removed), len(diff.Changed)),
+	).
+		At(candidatePath, 0, 0).
+		WithMetadata("artifact", relPath).
+		WithMetadata("diff", string(diffJSON)).
+		WithMetadata("type", "archive_content_diff").
+		Done()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestCompareIdenticalFile(t *testing.T) {
+	client := testClient(t)
+	resp := invokeCompare(t, client, testdataDir(t))
+
+	found := findByRule(resp.GetFindings(), "ARTINT-020")
+	hasSameTxt := false
+	for _, f := range found {
+		if f.GetMetadata()["artifact"] == "same.txt" {
+			hasSameTxt = true
+		}
+	}
+	if !hasSameTxt {
+		t.Error("expected same.txt to be reported as byte-identical")
+	}
+}
+
+func TestCompareArchiveContentDiff(t *testing.T) {
+	client := testClient(t)
+	resp := invokeCompare(t, client, testdataDir(t))
+
+	found := findByRule(resp.GetFindings(), "ARTINT-021")
+	hasRelease := false
+	for _, f := range found {
+		if f.GetMetadata()["artifact"] == "release.tar.gz" {
+			hasRelease = true
+			if f.GetMetadata()["diff"] == "" {
+				t.Error("expected diff metadata to be populated")
+			}
+		}
+	}
+	if !hasRelease {
+		t.Error("expected release.tar.gz to be reported with an archive content diff")
+	}
+}
+
+func TestCompareNonArchiveContentDiff(t *testing.T) {
+	client := testClient(t)
+	resp := invokeCompare(t, client, testdataDir(t))
+
+	found := findByRule(resp.GetFindings(), "ARTINT-022")
+	hasAppBin := false
+	for _, f := range found {
+		if f.GetMetadata()["artifact"] == "app.bin" {
+			hasAppBin = true
+		}
+	}
+	if !hasAppBin {
+		t.Error("expected app.bin to be reported as a non-archive content diff")
+	}
+}
+
+func invokeCompare(t *testing.T, client pluginv1.PluginServiceClient, base string) *pluginv1.InvokeToolResponse {
+	t.Helper()
+	input, err := structpb.NewStruct(map[string]any{
+		"baseline_root":  filepath.Join(base, "compare", "baseline"),
+		"candidate_root": filepath.Join(base, "compare", "candidate"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.InvokeTool(context.Background(), &pluginv1.InvokeToolRequest{
+		ToolName: "compare",
+		Input:    input,
+	})
+	if err != nil {
+		t.Fatalf("InvokeTool(compare): %v", err)
+	}
+	return resp
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// reGoSumLine matches go.sum lines: module version hash.
+var reGoSumLine = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(h1:\S+)$`)
+
+// reGoModRequire matches a single-line `require module version` directive,
+// tolerating a trailing `// indirect` (or other) comment the same way
+// reGoModRequireLine does for the block form.
+var reGoModRequire = regexp.MustCompile(`^\s*require\s+(\S+)\s+(\S+)\s*(?://.*)?$`)
+
+// reGoModRequireLine matches one `module version` line inside a `require
+// ( ... )` block.
+var reGoModRequireLine = regexp.MustCompile(`^\s*(\S+)\s+(\S+)\s*(?://.*)?$`)
+
+// sumdbOptions configures the opt-in sumdb cross-check.
+type sumdbOptions struct {
+	Enabled  bool
+	BaseURL  string
+	CacheDir string
+}
+
+// defaultSumDBBaseURL is Go's default checksum database, used when
+// verify_sumdb is enabled without an explicit base URL override.
+const defaultSumDBBaseURL = "https://sum.golang.org"
+
+// sumdbOptionsFromInput reads the opt-in `verify_sumdb` and
+// `sumdb_base_url` tool inputs.
+func sumdbOptionsFromInput(input map[string]any) sumdbOptions {
+	opts := sumdbOptions{
+		BaseURL:  defaultSumDBBaseURL,
+		CacheDir: filepath.Join(os.TempDir(), "nox-artifact-integrity", "sumdb"),
+	}
+
+	if enabled, ok := input["verify_sumdb"].(bool); ok {
+		opts.Enabled = enabled
+	}
+	if baseURL, ok := input["sumdb_base_url"].(string); ok && baseURL != "" {
+		opts.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+
+	return opts
+}
+
+// checkGoSumIntegrity validates go.sum: duplicate entries with
+// conflicting hashes (ARTINT-003), local hashes that disagree with the
+// sumdb's authoritative record when verify_sumdb is enabled (ARTINT-006),
+// and go.mod requires with no corresponding go.sum entry (ARTINT-007).
+func checkGoSumIntegrity(ctx context.Context, resp *sdk.ResponseBuilder, filePath string, opts sumdbOptions) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	seen := make(map[string]string)       // module@version -> hash
+	present := make(map[string]bool)      // module@version -> has zip hash
+	presentGoMod := make(map[string]bool) // module@version -> has /go.mod hash
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		matches := reGoSumLine.FindStringSubmatch(line)
+		if len(matches) != 4 {
+			continue
+		}
+
+		module := matches[1]
+		ver := matches[2]
+		hash := matches[3]
+
+		if strings.HasSuffix(ver, "/go.mod") {
+			presentGoMod[module+"@"+strings.TrimSuffix(ver, "/go.mod")] = true
+		} else {
+			present[module+"@"+ver] = true
+		}
+
+		key := module + "@" + ver
+		if existing, ok := seen[key]; ok && existing != hash {
+			resp.Finding(
+				"ARTINT-003",
+				sdk.SeverityCritical,
+				sdk.ConfidenceHigh,
+				fmt.Sprintf("Duplicate go.sum entry with different hash for %s", key),
+			).
+				At(filePath, lineNum, lineNum).
+				WithMetadata("module", module).
+				WithMetadata("version", ver).
+				WithMetadata("type", "checksum_mismatch").
+				Done()
+		}
+		seen[key] = hash
+
+		if opts.Enabled && !strings.HasSuffix(ver, "/go.mod") {
+			checkSumDB(ctx, resp, filePath, lineNum, module, ver, hash, opts)
+		}
+	}
+
+	checkGoModRequiresHaveSum(resp, filePath, present, presentGoMod)
+}
+
+// checkGoModRequiresHaveSum reads the go.mod next to go.sum and flags any
+// required module missing its zip or /go.mod entry in go.sum -- the gap
+// that lets `GOFLAGS=-mod=mod` or `-insecure` silently skip verification.
+func checkGoModRequiresHaveSum(resp *sdk.ResponseBuilder, goSumPath string, present, presentGoMod map[string]bool) {
+	goModPath := filepath.Join(filepath.Dir(goSumPath), "go.mod")
+	f, err := os.Open(goModPath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	inRequireBlock := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "require (" {
+			inRequireBlock = true
+			continue
+		}
+		if inRequireBlock && trimmed == ")" {
+			inRequireBlock = false
+			continue
+		}
+
+		var module, ver string
+		switch {
+		case inRequireBlock:
+			if m := reGoModRequireLine.FindStringSubmatch(trimmed); m != nil {
+				module, ver = m[1], m[2]
+			}
+		default:
+			if m := reGoModRequire.FindStringSubmatch(line); m != nil {
+				module, ver = m[1], m[2]
+			}
+		}
+		if module == "" || strings.HasPrefix(module, "//") {
+			continue
+		}
+
+		key := module + "@" + ver
+		if !present[key] || !presentGoMod[key] {
+			resp.Finding(
+				"ARTINT-007",
+				sdk.SeverityHigh,
+				sdk.ConfidenceMedium,
+				fmt.Sprintf("go.mod requires %s but go.sum has no complete entry for it", key),
+			).
+				At(goModPath, 0, 0).
+				WithMetadata("module", module).
+				WithMetadata("version", ver).
+				WithMetadata("type", "missing_sum").
+				Done()
+		}
+	}
+}
+
+// checkSumDB looks up module@version in the configured sumdb (disk-cached
+// by module@version) and compares its authoritative h1 hash against the
+// local go.sum entry. Network failures are treated as "can't verify" and
+// skipped silently rather than reported, since sumdb access may simply be
+// unavailable in this environment.
+func checkSumDB(ctx context.Context, resp *sdk.ResponseBuilder, filePath string, lineNum int, module, ver, localHash string, opts sumdbOptions) {
+	body, err := fetchSumDBLookup(ctx, module, ver, opts)
+	if err != nil {
+		return
+	}
+
+	zipHash, _, err := parseSumDBLookup(body, ver)
+	if err != nil {
+		return
+	}
+
+	if zipHash != localHash {
+		resp.Finding(
+			"ARTINT-006",
+			sdk.SeverityCritical,
+			sdk.ConfidenceHigh,
+			fmt.Sprintf("go.sum hash for %s@%s disagrees with %s", module, ver, opts.BaseURL),
+		).
+			At(filePath, lineNum, lineNum).
+			WithMetadata("module", module).
+			WithMetadata("version", ver).
+			WithMetadata("expected", zipHash).
+			WithMetadata("actual", localHash).
+			WithMetadata("type", "sumdb_mismatch").
+			Done()
+	}
+}
+
+// fetchSumDBLookup returns the sumdb's /lookup/<module>@<version> response
+// body, reading it from opts.CacheDir if already cached there.
+func fetchSumDBLookup(ctx context.Context, module, ver string, opts sumdbOptions) ([]byte, error) {
+	cachePath := filepath.Join(opts.CacheDir, sumDBCacheKey(module, ver))
+	if data, err := os.ReadFile(cachePath); err == nil {
+		return data, nil
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/lookup/%s@%s", opts.BaseURL, module, ver)
+	httpReq, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpResp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sumdb lookup for %s@%s: status %d", module, ver, httpResp.StatusCode)
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(opts.CacheDir, 0o755); err == nil {
+		_ = os.WriteFile(cachePath, body, 0o644)
+	}
+
+	return body, nil
+}
+
+// sumDBCacheKey turns a module@version pair into a filesystem-safe cache
+// filename.
+func sumDBCacheKey(module, ver string) string {
+	sum := sha256.Sum256([]byte(module + "@" + ver))
+	return hex.EncodeToString(sum[:]) + ".cache"
+}
+
+// parseSumDBLookup parses a sumdb lookup response: a signed note whose
+// second line is "<version> h1:<hash>" and third line is
+// "<version>/go.mod h1:<hash>".
+func parseSumDBLookup(body []byte, ver string) (zipHash, goModHash string, err error) {
+	lines := strings.Split(string(body), "\n")
+	if len(lines) < 3 {
+		return "", "", fmt.Errorf("sumdb response too short")
+	}
+
+	zipHash, err = parseSumDBHashLine(lines[1], ver)
+	if err != nil {
+		return "", "", err
+	}
+	goModHash, err = parseSumDBHashLine(lines[2], ver+"/go.mod")
+	if err != nil {
+		return "", "", err
+	}
+
+	return zipHash, goModHash, nil
+}
+
+// parseSumDBHashLine parses one "<versionOrVersion/go.mod> h1:<hash>" line
+// and returns the hash, verifying the version field matches want.
+func parseSumDBHashLine(line, want string) (string, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 2 || fields[0] != want || !strings.HasPrefix(fields[1], "h1:") {
+		return "", fmt.Errorf("malformed sumdb line %q", line)
+	}
+	return fields[1], nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+func TestCheckGoModRequiresHaveSum(t *testing.T) {
+	resp := sdk.NewResponse()
+	goSumPath := filepath.Join("testdata", "lockfiles", "gomod-missing-sum", "go.sum")
+
+	checkGoSumIntegrity(context.Background(), resp, goSumPath, sumdbOptions{})
+
+	found := findByRule(resp.Build().GetFindings(), "ARTINT-007")
+	if len(found) == 0 {
+		t.Fatal("expected ARTINT-007 for module missing from go.sum")
+	}
+
+	hasUncovered := false
+	for _, f := range found {
+		if f.GetMetadata()["module"] == "github.com/uncovered/dep" {
+			hasUncovered = true
+		}
+		if f.GetMetadata()["module"] == "github.com/covered/dep" {
+			t.Error("github.com/covered/dep has a complete go.sum entry and should not be flagged")
+		}
+	}
+	if !hasUncovered {
+		t.Error("expected github.com/uncovered/dep to be flagged as missing from go.sum")
+	}
+}
+
+func TestCheckGoModRequiresHaveSumSingleLineWithComment(t *testing.T) {
+	resp := sdk.NewResponse()
+	goSumPath := filepath.Join("testdata", "lockfiles", "gomod-singleline-indirect", "go.sum")
+
+	checkGoSumIntegrity(context.Background(), resp, goSumPath, sumdbOptions{})
+
+	found := findByRule(resp.Build().GetFindings(), "ARTINT-007")
+	hasUncovered := false
+	for _, f := range found {
+		if f.GetMetadata()["module"] == "github.com/uncovered/dep" {
+			hasUncovered = true
+		}
+		if f.GetMetadata()["module"] == "github.com/covered/dep" {
+			t.Error("github.com/covered/dep has a complete go.sum entry and should not be flagged")
+		}
+	}
+	if !hasUncovered {
+		t.Error("expected single-line require with a trailing // indirect comment to be flagged as missing from go.sum")
+	}
+}
+
+func TestParseSumDBLookup(t *testing.T) {
+	body := "github.com/covered/dep@v1.2.3\nv1.2.3 h1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=\nv1.2.3/go.mod h1:BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB=\n"
+
+	zipHash, goModHash, err := parseSumDBLookup([]byte(body), "v1.2.3")
+	if err != nil {
+		t.Fatalf("parseSumDBLookup: %v", err)
+	}
+	if zipHash != "h1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=" {
+		t.Errorf("unexpected zip hash: %s", zipHash)
+	}
+	if goModHash != "h1:BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB=" {
+		t.Errorf("unexpected go.mod hash: %s", goModHash)
+	}
+}
+
+func TestParseSumDBLookupMalformed(t *testing.T) {
+	_, _, err := parseSumDBLookup([]byte("too short\n"), "v1.2.3")
+	if err == nil {
+		t.Error("expected error for truncated sumdb response")
+	}
+}
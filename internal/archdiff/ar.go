@@ -0,0 +1,67 @@
+package archdiff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// arMagic is the fixed 8-byte magic at the start of a Unix ar archive.
+const arMagic = "!<arch>\n"
+
+// arEntry is one file within a Unix ar archive (the container format used
+// by .deb packages and static libraries).
+type arEntry struct {
+	Name string
+	Data []byte
+}
+
+// readAr parses a Unix ar archive into its member entries.
+func readAr(r io.Reader) ([]arEntry, error) {
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(arMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, fmt.Errorf("archdiff: reading ar magic: %w", err)
+	}
+	if string(magic) != arMagic {
+		return nil, fmt.Errorf("archdiff: not a Unix ar archive")
+	}
+
+	var entries []arEntry
+	for {
+		header := make([]byte, 60)
+		_, err := io.ReadFull(br, header)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("archdiff: reading ar header: %w", err)
+		}
+
+		name := strings.TrimRight(string(header[0:16]), " ")
+		name = strings.TrimSuffix(name, "/") // GNU ar appends a trailing slash
+		sizeField := strings.TrimSpace(string(header[48:58]))
+		size, err := strconv.ParseInt(sizeField, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("archdiff: malformed ar size field %q: %w", sizeField, err)
+		}
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(br, data); err != nil {
+			return nil, fmt.Errorf("archdiff: reading ar entry %s: %w", name, err)
+		}
+		entries = append(entries, arEntry{Name: name, Data: data})
+
+		// Entries are padded to an even number of bytes.
+		if size%2 != 0 {
+			if _, err := br.Discard(1); err != nil {
+				return nil, fmt.Errorf("archdiff: discarding ar padding: %w", err)
+			}
+		}
+	}
+
+	return entries, nil
+}
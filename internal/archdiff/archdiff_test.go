@@ -0,0 +1,83 @@
+package archdiff
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		want Format
+	}{
+		{"release-1.0.0.tar.gz", FormatTarGz},
+		{"release-1.0.0.tgz", FormatTarGz},
+		{"release-1.0.0.zip", FormatZip},
+		{"app.jar", FormatZip},
+		{"pkg-1.0-py3-none-any.whl", FormatZip},
+		{"pkg_1.0_amd64.deb", FormatDeb},
+		{"pkg-1.0.rpm", FormatUnknown},
+		{"README.md", FormatUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectFormat(tt.name); got != tt.want {
+				t.Errorf("DetectFormat(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareMembersTarGz(t *testing.T) {
+	baseline, err := ReadMembers(filepath.Join("testdata", "baseline.tar.gz"))
+	if err != nil {
+		t.Fatalf("ReadMembers(baseline): %v", err)
+	}
+	candidate, err := ReadMembers(filepath.Join("testdata", "candidate.tar.gz"))
+	if err != nil {
+		t.Fatalf("ReadMembers(candidate): %v", err)
+	}
+
+	diff := CompareMembers(baseline, candidate)
+	if diff.Equal() {
+		t.Fatal("expected a non-empty diff")
+	}
+	if len(diff.Added) != 1 || diff.Added[0] != "c.txt" {
+		t.Errorf("expected c.txt to be added, got %v", diff.Added)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Path != "b.txt" {
+		t.Fatalf("expected b.txt to be changed, got %v", diff.Changed)
+	}
+	if diff.Changed[0].TextDiff == "" {
+		t.Error("expected a text diff snippet for changed text member")
+	}
+}
+
+func TestCompareMembersZipIdentical(t *testing.T) {
+	baseline, err := ReadMembers(filepath.Join("testdata", "baseline.zip"))
+	if err != nil {
+		t.Fatalf("ReadMembers(baseline): %v", err)
+	}
+
+	diff := CompareMembers(baseline, baseline)
+	if !diff.Equal() {
+		t.Errorf("expected comparing an archive to itself to produce no diff, got %+v", diff)
+	}
+}
+
+func TestCompareMembersZipChanged(t *testing.T) {
+	baseline, err := ReadMembers(filepath.Join("testdata", "baseline.zip"))
+	if err != nil {
+		t.Fatalf("ReadMembers(baseline): %v", err)
+	}
+	candidate, err := ReadMembers(filepath.Join("testdata", "candidate.zip"))
+	if err != nil {
+		t.Fatalf("ReadMembers(candidate): %v", err)
+	}
+
+	diff := CompareMembers(baseline, candidate)
+	if len(diff.Changed) != 1 || diff.Changed[0].Path != "b.txt" {
+		t.Errorf("expected b.txt to be changed, got %+v", diff.Changed)
+	}
+}
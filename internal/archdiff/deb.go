@@ -0,0 +1,70 @@
+package archdiff
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// readDeb reads a .deb package: a Unix ar archive whose members include
+// control.tar.* and data.tar.* tarballs. Each tar member's entries are
+// surfaced as archdiff Members, prefixed with the ar member name they
+// came from so control and data files with the same path don't collide.
+//
+// Only the gzip and uncompressed tar compressions are supported, since
+// those are the only ones available from the standard library; xz- or
+// zstd-compressed debs (common on current Debian/Ubuntu) return an error
+// naming the member rather than silently skipping it.
+func readDeb(path string) ([]Member, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries, err := readAr(f)
+	if err != nil {
+		return nil, fmt.Errorf("archdiff: reading deb %s: %w", path, err)
+	}
+
+	var members []Member
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name, "control.tar") && !strings.HasPrefix(entry.Name, "data.tar") {
+			continue
+		}
+
+		tarMembers, err := readDebTarMember(entry)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range tarMembers {
+			m.Path = entry.Name + ":" + m.Path
+			members = append(members, m)
+		}
+	}
+
+	return members, nil
+}
+
+// readDebTarMember decompresses (if needed) and reads one ar member known
+// to be a tar archive.
+func readDebTarMember(entry arEntry) ([]Member, error) {
+	reader := bytes.NewReader(entry.Data)
+
+	switch {
+	case strings.HasSuffix(entry.Name, ".tar"):
+		return readTarMembers(tar.NewReader(reader))
+	case strings.HasSuffix(entry.Name, ".tar.gz"):
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("archdiff: opening gzip stream for %s: %w", entry.Name, err)
+		}
+		defer gz.Close()
+		return readTarMembers(tar.NewReader(gz))
+	default:
+		return nil, fmt.Errorf("archdiff: %s uses an unsupported compression (only tar and tar.gz are supported)", entry.Name)
+	}
+}
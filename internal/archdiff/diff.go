@@ -0,0 +1,135 @@
+package archdiff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangedMember describes one member present in both archives with
+// different content.
+type ChangedMember struct {
+	Path            string
+	BaselineSHA256  string
+	CandidateSHA256 string
+	TextDiff        string // empty unless both sides look like text
+}
+
+// Diff is the normalized comparison of two archives' member sets.
+type Diff struct {
+	Added   []string
+	Removed []string
+	Changed []ChangedMember
+}
+
+// Equal reports whether the two archives had no member differences at all.
+func (d Diff) Equal() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// CompareMembers diffs two archives' normalized member lists by path,
+// ignoring order, mtime, uid, and gid (none of which Member carries).
+func CompareMembers(baseline, candidate []Member) Diff {
+	baseIndex := indexByPath(baseline)
+	candIndex := indexByPath(candidate)
+
+	var diff Diff
+
+	for path, base := range baseIndex {
+		cand, ok := candIndex[path]
+		if !ok {
+			diff.Removed = append(diff.Removed, path)
+			continue
+		}
+		if base.SHA256 != cand.SHA256 {
+			diff.Changed = append(diff.Changed, ChangedMember{
+				Path:            path,
+				BaselineSHA256:  base.SHA256,
+				CandidateSHA256: cand.SHA256,
+				TextDiff:        textDiffIfApplicable(base, cand),
+			})
+		}
+	}
+	for path := range candIndex {
+		if _, ok := baseIndex[path]; !ok {
+			diff.Added = append(diff.Added, path)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Path < diff.Changed[j].Path })
+
+	return diff
+}
+
+func indexByPath(members []Member) map[string]Member {
+	idx := make(map[string]Member, len(members))
+	for _, m := range members {
+		idx[m.Path] = m
+	}
+	return idx
+}
+
+// maxDiffLines bounds the unified-diff snippet attached to a changed
+// member, so a single large nondeterministic file doesn't blow up a
+// finding's metadata.
+const maxDiffLines = 200
+
+// textDiffIfApplicable returns a bounded unified-diff snippet between two
+// changed members' content, or "" if either side isn't text, wasn't
+// retained (too large), or is identical.
+func textDiffIfApplicable(base, cand Member) string {
+	if base.Data == nil || cand.Data == nil {
+		return ""
+	}
+	if !looksLikeText(base.Data) || !looksLikeText(cand.Data) {
+		return ""
+	}
+	return unifiedDiff(string(base.Data), string(cand.Data))
+}
+
+// unifiedDiff produces a minimal line-level unified diff, bounded to
+// maxDiffLines of output. It does not attempt LCS alignment beyond a
+// naive line-by-line comparison -- good enough to surface the usual
+// nondeterminism sources (embedded timestamps, build paths) without
+// pulling in a diff library.
+func unifiedDiff(a, b string) string {
+	aLines := strings.Split(a, "\n")
+	bLines := strings.Split(b, "\n")
+
+	var out []string
+	max := len(aLines)
+	if len(bLines) > max {
+		max = len(bLines)
+	}
+
+	for i := 0; i < max && len(out) < maxDiffLines; i++ {
+		var al, bl string
+		haveA, haveB := i < len(aLines), i < len(bLines)
+		if haveA {
+			al = aLines[i]
+		}
+		if haveB {
+			bl = bLines[i]
+		}
+		switch {
+		case haveA && haveB && al == bl:
+			continue
+		case haveA && haveB:
+			out = append(out, fmt.Sprintf("-%s", al), fmt.Sprintf("+%s", bl))
+		case haveA:
+			out = append(out, fmt.Sprintf("-%s", al))
+		case haveB:
+			out = append(out, fmt.Sprintf("+%s", bl))
+		}
+	}
+
+	if len(out) == 0 {
+		return ""
+	}
+	if len(out) >= maxDiffLines {
+		out = append(out[:maxDiffLines], "... (truncated)")
+	}
+	return strings.Join(out, "\n")
+}
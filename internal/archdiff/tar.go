@@ -0,0 +1,59 @@
+package archdiff
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+)
+
+// readTarGz reads every regular file entry from a gzip-compressed tar
+// archive into a Member, retaining content for files small enough to be
+// worth diffing as text later.
+func readTarGz(path string) ([]Member, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("archdiff: opening gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	return readTarMembers(tar.NewReader(gz))
+}
+
+// readTarMembers drains a tar stream into Members, skipping directories
+// and non-regular entries (symlinks, devices) which don't carry content
+// to compare.
+func readTarMembers(tr *tar.Reader) ([]Member, error) {
+	var members []Member
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("archdiff: reading tar entry: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("archdiff: reading tar entry %s: %w", hdr.Name, err)
+		}
+
+		m := Member{Path: hdr.Name, SHA256: hashBytes(data), Size: int64(len(data))}
+		if len(data) <= maxTextDiffBytes {
+			m.Data = data
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
@@ -0,0 +1,42 @@
+package archdiff
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+)
+
+// readZip reads every non-directory entry of a zip archive (also used for
+// .jar and .whl, which are zip files with different conventional
+// extensions) into a Member.
+func readZip(path string) ([]Member, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("archdiff: opening zip: %w", err)
+	}
+	defer zr.Close()
+
+	var members []Member
+	for _, entry := range zr.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, fmt.Errorf("archdiff: opening zip entry %s: %w", entry.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("archdiff: reading zip entry %s: %w", entry.Name, err)
+		}
+
+		m := Member{Path: entry.Name, SHA256: hashBytes(data), Size: int64(len(data))}
+		if len(data) <= maxTextDiffBytes {
+			m.Data = data
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
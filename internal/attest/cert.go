@@ -0,0 +1,112 @@
+package attest
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+)
+
+// Identity is an allowed signer identity/issuer pair, as configured by the
+// caller (typically sourced from tool input). Both fields are matched
+// literally against the certificate's Subject Alternative Name and its
+// OIDC issuer extension.
+type Identity struct {
+	SAN    string
+	Issuer string
+}
+
+// fulcioIssuerOID is the X.509 extension OID Fulcio stamps with the OIDC
+// issuer URL that authenticated the signing request.
+var fulcioIssuerOID = []int{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+
+// VerifySigner checks that certDER chains to one of roots and that its SAN
+// and OIDC issuer extension match one of the allowed identities. It
+// returns the matching identity's SAN on success.
+//
+// signingTime anchors the certificate's validity check: Fulcio certificates
+// are short-lived (around ten minutes), so verifying against wall-clock
+// "now" fails for any attestation more than a few minutes old. Callers
+// should pass the bundle's transparency log inclusion time here; a zero
+// signingTime falls back to the current time for bundles that carry none.
+func VerifySigner(certDER []byte, roots *x509.CertPool, allowed []Identity, signingTime time.Time) (string, error) {
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return "", fmt.Errorf("parsing signing certificate: %w", err)
+	}
+
+	if roots != nil {
+		verifyOpts := x509.VerifyOptions{
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning, x509.ExtKeyUsageAny},
+		}
+		if !signingTime.IsZero() {
+			verifyOpts.CurrentTime = signingTime
+		}
+		if _, err := cert.Verify(verifyOpts); err != nil {
+			return "", fmt.Errorf("certificate does not chain to a trusted root: %w", err)
+		}
+	}
+
+	issuer := extensionValue(cert, fulcioIssuerOID)
+	sans := certificateSANs(cert)
+
+	for _, id := range allowed {
+		if id.Issuer != "" && id.Issuer != issuer {
+			continue
+		}
+		for _, san := range sans {
+			if san == id.SAN {
+				return san, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("signer identity not in allowed list (sans=%v issuer=%q)", sans, issuer)
+}
+
+// certificateSANs collects every SAN form (email, URI, DNS) a Fulcio
+// certificate might carry the signer's identity in.
+func certificateSANs(cert *x509.Certificate) []string {
+	var sans []string
+	sans = append(sans, cert.EmailAddresses...)
+	sans = append(sans, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
+
+// extensionValue returns the raw bytes of the named extension as a string,
+// or "" if the certificate does not carry it.
+func extensionValue(cert *x509.Certificate, oid []int) string {
+	for _, ext := range cert.Extensions {
+		if oidEqual(ext.Id, oid) {
+			return string(trimASN1String(ext.Value))
+		}
+	}
+	return ""
+}
+
+func oidEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// trimASN1String strips the leading ASN.1 UTF8String tag/length header (if
+// present) from an extension's DER-encoded value, leaving the plain string.
+func trimASN1String(v []byte) []byte {
+	if len(v) >= 2 && v[0] == 0x0c {
+		length := int(v[1])
+		if len(v) >= 2+length {
+			return v[2 : 2+length]
+		}
+	}
+	return v
+}
@@ -0,0 +1,99 @@
+package attest
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+)
+
+func testSignerCert(t *testing.T) *x509.Certificate {
+	t.Helper()
+	der := readTestdata(t, "signer.der")
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing testdata/signer.der: %v", err)
+	}
+	return cert
+}
+
+func testFulcioRoots(t *testing.T) *x509.CertPool {
+	t.Helper()
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(readTestdata(t, "fulcio-root.pem")) {
+		t.Fatal("failed to load testdata/fulcio-root.pem into a cert pool")
+	}
+	return pool
+}
+
+func TestVerifySignerWithinValidityWindow(t *testing.T) {
+	cert := testSignerCert(t)
+	roots := testFulcioRoots(t)
+	signingTime := cert.NotBefore.Add(5 * time.Minute)
+
+	san, err := VerifySigner(cert.Raw, roots, []Identity{
+		{SAN: "signer@example.com", Issuer: "https://accounts.example.com"},
+	}, signingTime)
+	if err != nil {
+		t.Fatalf("VerifySigner at signing time: %v", err)
+	}
+	if san != "signer@example.com" {
+		t.Errorf("expected matched SAN signer@example.com, got %q", san)
+	}
+}
+
+func TestVerifySignerExpiredAtCurrentTime(t *testing.T) {
+	cert := testSignerCert(t)
+	roots := testFulcioRoots(t)
+
+	// Fulcio certs are valid for only a few minutes; verifying long after
+	// the signing time (here, against a point well past NotAfter) must
+	// fail rather than silently succeed.
+	longAfterSigning := cert.NotAfter.Add(1 * time.Hour)
+
+	_, err := VerifySigner(cert.Raw, roots, []Identity{
+		{SAN: "signer@example.com", Issuer: "https://accounts.example.com"},
+	}, longAfterSigning)
+	if err == nil {
+		t.Fatal("expected VerifySigner to fail for a certificate no longer valid at the given time")
+	}
+}
+
+func TestVerifySignerIssuerMismatch(t *testing.T) {
+	cert := testSignerCert(t)
+	roots := testFulcioRoots(t)
+	signingTime := cert.NotBefore.Add(5 * time.Minute)
+
+	_, err := VerifySigner(cert.Raw, roots, []Identity{
+		{SAN: "signer@example.com", Issuer: "https://wrong-issuer.example.com"},
+	}, signingTime)
+	if err == nil {
+		t.Fatal("expected VerifySigner to reject a SAN match under the wrong issuer")
+	}
+}
+
+func TestVerifySignerUnknownIdentity(t *testing.T) {
+	cert := testSignerCert(t)
+	roots := testFulcioRoots(t)
+	signingTime := cert.NotBefore.Add(5 * time.Minute)
+
+	_, err := VerifySigner(cert.Raw, roots, []Identity{
+		{SAN: "someone-else@example.com"},
+	}, signingTime)
+	if err == nil {
+		t.Fatal("expected VerifySigner to reject a SAN not in the allowed list")
+	}
+}
+
+func TestVerifySignerNoRootsSkipsChainCheck(t *testing.T) {
+	cert := testSignerCert(t)
+
+	san, err := VerifySigner(cert.Raw, nil, []Identity{
+		{SAN: "signer@example.com"},
+	}, time.Time{})
+	if err != nil {
+		t.Fatalf("VerifySigner with no roots: %v", err)
+	}
+	if san != "signer@example.com" {
+		t.Errorf("expected matched SAN signer@example.com, got %q", san)
+	}
+}
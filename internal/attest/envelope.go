@@ -0,0 +1,142 @@
+// Package attest verifies Sigstore/cosign bundles and in-toto/DSSE
+// attestations against the on-disk artifacts they describe. It is kept
+// free of network calls so it can be exercised entirely from fixtures.
+package attest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// dsseEnvelopePayloadType is the only payload type this package knows how
+// to interpret; anything else is passed through as an unsupported error.
+const dsseEnvelopePayloadType = "application/vnd.in-toto+json"
+
+// DSSESignature is a single signature entry on a DSSE envelope. Cert, when
+// present, is the base64 DER certificate that produced Sig.
+type DSSESignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+	Cert  string `json:"cert,omitempty"`
+}
+
+// DSSEEnvelope is the minimal Dead Simple Signing Envelope shape used by
+// in-toto attestations: https://github.com/secure-systems-lab/dsse.
+type DSSEEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []DSSESignature `json:"signatures"`
+}
+
+// sigstoreCertificate is the verificationMaterial.certificate.rawBytes
+// shape used by the Sigstore bundle format.
+type sigstoreCertificate struct {
+	RawBytes string `json:"rawBytes"`
+}
+
+// sigstoreTlogEntry is the subset of a Rekor transparency log entry this
+// package reads: the time the entry (and the short-lived Fulcio cert that
+// produced it) was included in the log.
+type sigstoreTlogEntry struct {
+	IntegratedTime string `json:"integratedTime"`
+}
+
+type sigstoreVerificationMaterial struct {
+	Certificate *sigstoreCertificate `json:"certificate"`
+	TlogEntries []sigstoreTlogEntry  `json:"tlogEntries"`
+}
+
+// sigstoreBundle is the envelope shape produced by `cosign attest --bundle`
+// and friends: a DSSE envelope plus the verification material (signing
+// certificate, transparency log entry) needed to trust it.
+type sigstoreBundle struct {
+	VerificationMaterial *sigstoreVerificationMaterial `json:"verificationMaterial"`
+	DSSEEnvelope         *DSSEEnvelope                 `json:"dsseEnvelope"`
+}
+
+// Bundle is the normalized result of parsing either a raw DSSE envelope
+// (.intoto.jsonl, .att) or a Sigstore bundle (.sigstore, .bundle.json,
+// cosign.bundle) -- both carry a DSSE envelope, only the bundle form also
+// carries embedded verification material.
+type Bundle struct {
+	Envelope    DSSEEnvelope
+	Certificate []byte    // DER-encoded signing certificate, if embedded
+	SigningTime time.Time // transparency log inclusion time, zero if absent
+}
+
+// ParseBundle accepts the raw bytes of an attestation file and returns the
+// DSSE envelope it carries, decoding the embedded signing certificate if
+// the file is a Sigstore bundle rather than a bare DSSE envelope.
+func ParseBundle(data []byte) (*Bundle, error) {
+	var bundle sigstoreBundle
+	if err := json.Unmarshal(data, &bundle); err == nil && bundle.DSSEEnvelope != nil {
+		b := &Bundle{Envelope: *bundle.DSSEEnvelope}
+		if bundle.VerificationMaterial != nil && bundle.VerificationMaterial.Certificate != nil {
+			raw, err := base64.StdEncoding.DecodeString(bundle.VerificationMaterial.Certificate.RawBytes)
+			if err != nil {
+				return nil, fmt.Errorf("decoding embedded certificate: %w", err)
+			}
+			b.Certificate = raw
+		}
+		if bundle.VerificationMaterial != nil {
+			if t, ok := earliestTlogTime(bundle.VerificationMaterial.TlogEntries); ok {
+				b.SigningTime = t
+			}
+		}
+		return b, nil
+	}
+
+	var env DSSEEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("parsing DSSE envelope: %w", err)
+	}
+	if env.Payload == "" {
+		return nil, fmt.Errorf("not a DSSE envelope or Sigstore bundle")
+	}
+	return &Bundle{Envelope: env}, nil
+}
+
+// Statement decodes and unmarshals the envelope's payload as an in-toto
+// Statement. It returns an error if the payload type is not the in-toto
+// statement type this package understands.
+func (b *Bundle) Statement() (*Statement, error) {
+	if b.Envelope.PayloadType != dsseEnvelopePayloadType {
+		return nil, fmt.Errorf("unsupported payload type %q", b.Envelope.PayloadType)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(b.Envelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("decoding payload: %w", err)
+	}
+
+	var stmt Statement
+	if err := json.Unmarshal(raw, &stmt); err != nil {
+		return nil, fmt.Errorf("parsing in-toto statement: %w", err)
+	}
+	return &stmt, nil
+}
+
+// earliestTlogTime returns the earliest transparency log inclusion time
+// among entries. A bundle's signing certificate is only valid for the few
+// minutes around when it was actually used to sign, so this -- not the
+// time the scan happens to run -- is what certificate chain verification
+// must be anchored to.
+func earliestTlogTime(entries []sigstoreTlogEntry) (time.Time, bool) {
+	var earliest time.Time
+	found := false
+	for _, e := range entries {
+		seconds, err := strconv.ParseInt(e.IntegratedTime, 10, 64)
+		if err != nil {
+			continue
+		}
+		t := time.Unix(seconds, 0)
+		if !found || t.Before(earliest) {
+			earliest = t
+			found = true
+		}
+	}
+	return earliest, found
+}
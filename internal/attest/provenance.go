@@ -0,0 +1,91 @@
+package attest
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Subject is an in-toto Statement subject: the artifact the attestation
+// describes, identified by digest.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// provenancePredicate is the subset of a SLSA provenance predicate needed
+// to infer a build's SLSA level.
+type provenancePredicate struct {
+	BuildType string `json:"buildType"`
+	Builder   struct {
+		ID string `json:"id"`
+	} `json:"builder"`
+}
+
+// Statement is a minimal in-toto Statement (https://in-toto.io/Statement/v0.1).
+// PredicateRaw is left undecoded since only provenance predicates are
+// understood here; callers pass it to SLSALevel to interpret it.
+type Statement struct {
+	Type          string          `json:"_type"`
+	PredicateType string          `json:"predicateType"`
+	Subject       []Subject       `json:"subject"`
+	PredicateRaw  json.RawMessage `json:"predicate"`
+}
+
+// KnownSLSAProvenanceTypes are the predicateType URIs this package
+// recognizes as SLSA provenance.
+var KnownSLSAProvenanceTypes = map[string]bool{
+	"https://slsa.dev/provenance/v0.2": true,
+	"https://slsa.dev/provenance/v1":   true,
+}
+
+// SLSALevel inspects a provenance predicate's buildType and builder.id and
+// returns the maintainers' best guess at the asserted SLSA level. This is
+// a heuristic, not an authoritative determination: true level attestation
+// requires verifying the builder's own controls, which is out of scope
+// for a local file scan.
+func SLSALevel(predicate json.RawMessage) int {
+	var p provenancePredicate
+	if err := json.Unmarshal(predicate, &p); err != nil {
+		return 0
+	}
+
+	switch {
+	case p.Builder.ID == "":
+		return 0
+	case hasAny(p.Builder.ID, hostedBuilderMarkers) && hasAny(p.BuildType, hostedBuildTypeMarkers):
+		// A recognized hosted builder (GitHub Actions, GitLab CI, Cloud
+		// Build, etc.) running a generator-style build type is a good
+		// signal for isolated, ephemeral, provenance-generating builds.
+		return 3
+	case hasAny(p.Builder.ID, hostedBuilderMarkers):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// hostedBuilderMarkers are substrings of builder.id values that indicate a
+// well-known hosted build platform rather than a self-hosted or unknown one.
+var hostedBuilderMarkers = []string{
+	"github.com/actions",
+	"github.com/slsa-framework",
+	"gitlab.com",
+	"cloudbuild.googleapis.com",
+}
+
+// hostedBuildTypeMarkers are substrings of buildType values that indicate
+// the builder generated provenance itself rather than having it asserted
+// by an untrusted third party.
+var hostedBuildTypeMarkers = []string{
+	"slsa-framework/slsa-github-generator",
+	"generic_generator",
+}
+
+func hasAny(s string, markers []string) bool {
+	for _, m := range markers {
+		if strings.Contains(s, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package attest
+
+import (
+	"crypto/x509"
+	"fmt"
+)
+
+// Options configures attestation verification. Roots and Allowed are
+// typically populated from tool input; both are optional, in which case
+// the corresponding checks in Result are left unset rather than failed.
+type Options struct {
+	Roots   *x509.CertPool
+	Allowed []Identity
+}
+
+// Result captures every fact VerifyBundle could establish about an
+// attestation, so callers (the scanner) can decide which findings to
+// raise rather than this package knowing about findings at all.
+type Result struct {
+	Statement *Statement
+
+	DigestMatches  bool
+	DigestErr      error
+	KnownPredicate bool
+	PredicateType  string
+	SLSALevel      int
+	SignerIdentity string // non-empty only if a certificate was verified
+	SignerErr      error
+}
+
+// VerifyBundle parses an attestation file's bytes and checks its subject
+// digest against artifactSHA256 (the hex-encoded SHA-256 of the artifact
+// the attestation was found alongside), classifies its predicate type and
+// SLSA level, and, if the bundle embeds a signing certificate, verifies it
+// against opts.
+func VerifyBundle(data []byte, artifactSHA256 string, opts Options) (*Result, error) {
+	bundle, err := ParseBundle(data)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := bundle.Statement()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{
+		Statement:     stmt,
+		PredicateType: stmt.PredicateType,
+	}
+
+	res.DigestMatches, res.DigestErr = verifySubjectDigest(stmt.Subject, artifactSHA256)
+	res.KnownPredicate = KnownSLSAProvenanceTypes[stmt.PredicateType]
+	if res.KnownPredicate {
+		res.SLSALevel = SLSALevel(stmt.PredicateRaw)
+	}
+
+	if len(bundle.Certificate) > 0 {
+		res.SignerIdentity, res.SignerErr = VerifySigner(bundle.Certificate, opts.Roots, opts.Allowed, bundle.SigningTime)
+	}
+
+	return res, nil
+}
+
+// verifySubjectDigest reports whether any subject in subjects declares the
+// given SHA-256 digest.
+func verifySubjectDigest(subjects []Subject, artifactSHA256 string) (bool, error) {
+	if len(subjects) == 0 {
+		return false, fmt.Errorf("attestation has no subjects")
+	}
+
+	for _, s := range subjects {
+		if digest, ok := s.Digest["sha256"]; ok && digest == artifactSHA256 {
+			return true, nil
+		}
+	}
+
+	return false, fmt.Errorf("no subject digest matches artifact sha256 %s", artifactSHA256)
+}
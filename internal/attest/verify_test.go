@@ -0,0 +1,98 @@
+package attest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyBundleDigestMatch(t *testing.T) {
+	data := readTestdata(t, "valid.intoto.jsonl")
+	artifact := readTestdata(t, "artifact.tar.gz")
+	sum := sha256.Sum256(artifact)
+	digest := hex.EncodeToString(sum[:])
+
+	result, err := VerifyBundle(data, digest, Options{})
+	if err != nil {
+		t.Fatalf("VerifyBundle: %v", err)
+	}
+	if !result.DigestMatches {
+		t.Error("expected DigestMatches to be true for matching artifact digest")
+	}
+	if !result.KnownPredicate {
+		t.Error("expected predicateType v0.2 to be a known SLSA provenance type")
+	}
+	if result.SLSALevel != 3 {
+		t.Errorf("expected SLSA level 3 for hosted generator build, got %d", result.SLSALevel)
+	}
+}
+
+func TestVerifyBundleDigestMismatch(t *testing.T) {
+	data := readTestdata(t, "valid.intoto.jsonl")
+
+	result, err := VerifyBundle(data, "0000000000000000000000000000000000000000000000000000000000000000", Options{})
+	if err != nil {
+		t.Fatalf("VerifyBundle: %v", err)
+	}
+	if result.DigestMatches {
+		t.Error("expected DigestMatches to be false for mismatched digest")
+	}
+}
+
+func TestVerifyBundleTrustsSignerAtTransparencyLogTime(t *testing.T) {
+	data := readTestdata(t, "signed.sigstore")
+	artifact := readTestdata(t, "artifact.tar.gz")
+	sum := sha256.Sum256(artifact)
+	digest := hex.EncodeToString(sum[:])
+
+	roots := testFulcioRoots(t)
+	result, err := VerifyBundle(data, digest, Options{
+		Roots:   roots,
+		Allowed: []Identity{{SAN: "signer@example.com", Issuer: "https://accounts.example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("VerifyBundle: %v", err)
+	}
+	if !result.DigestMatches {
+		t.Error("expected DigestMatches to be true for matching artifact digest")
+	}
+	if result.SignerErr != nil {
+		t.Errorf("expected signer to verify against the bundle's transparency log time, got: %v", result.SignerErr)
+	}
+	if result.SignerIdentity != "signer@example.com" {
+		t.Errorf("expected signer identity signer@example.com, got %q", result.SignerIdentity)
+	}
+}
+
+func TestSLSALevel(t *testing.T) {
+	tests := []struct {
+		name      string
+		predicate string
+		want      int
+	}{
+		{"no builder", `{"buildType":"x"}`, 0},
+		{"hosted generator", `{"buildType":"https://github.com/slsa-framework/slsa-github-generator/generic_generator","builder":{"id":"https://github.com/actions/runner"}}`, 3},
+		{"hosted without generator", `{"buildType":"custom","builder":{"id":"https://gitlab.com/ci"}}`, 2},
+		{"self-hosted", `{"buildType":"custom","builder":{"id":"https://example.com/my-builder"}}`, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SLSALevel([]byte(tt.predicate))
+			if got != tt.want {
+				t.Errorf("SLSALevel(%s) = %d, want %d", tt.predicate, got, tt.want)
+			}
+		})
+	}
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading testdata/%s: %v", name, err)
+	}
+	return data
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// reBundlerChecksumLine matches a line in a Gemfile.lock `CHECKSUMS`
+// section, e.g. "  rack (2.2.8) sha256=1f2a3b...".
+var reBundlerChecksumLine = regexp.MustCompile(`^\s{2}(\S+)\s+\(([^)]+)\)\s+sha256=(\S+)\s*$`)
+
+// checkGemfileLockIntegrity validates the `CHECKSUMS` section Bundler adds
+// to Gemfile.lock (added in Bundler 2.5+; lockfiles predating that
+// feature have no CHECKSUMS section and nothing to check here).
+func checkGemfileLockIntegrity(resp *sdk.ResponseBuilder, filePath string) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	inChecksums := false
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "CHECKSUMS" {
+			inChecksums = true
+			continue
+		}
+		if inChecksums && line != "" && !strings.HasPrefix(line, "  ") {
+			inChecksums = false
+		}
+		if !inChecksums {
+			continue
+		}
+
+		m := reBundlerChecksumLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		gem, version, checksum := m[1], m[2], m[3]
+		if len(checksum) != 64 || !isHex(checksum) {
+			resp.Finding(
+				"ARTINT-005",
+				sdk.SeverityMedium,
+				sdk.ConfidenceHigh,
+				fmt.Sprintf("Malformed Gemfile.lock checksum for %s (%s)", gem, version),
+			).
+				At(filePath, 0, 0).
+				WithMetadata("package", gem).
+				WithMetadata("version", version).
+				WithMetadata("type", "invalid_integrity").
+				Done()
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// npmLockfile represents a minimal package-lock.json structure.
+type npmLockfile struct {
+	Packages map[string]struct {
+		Version   string `json:"version"`
+		Resolved  string `json:"resolved"`
+		Integrity string `json:"integrity"`
+	} `json:"packages"`
+}
+
+// checkNPMLockfileIntegrity checks package-lock.json for missing or
+// malformed SRI hashes, and, when a matching tarball can be found under
+// artifactDirs, recomputes its hash and flags a mismatch.
+func checkNPMLockfileIntegrity(resp *sdk.ResponseBuilder, filePath string, artifactDirs []string) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return
+	}
+
+	var lockfile npmLockfile
+	if err := json.Unmarshal(data, &lockfile); err != nil {
+		return
+	}
+
+	for pkgPath, pkg := range lockfile.Packages {
+		if pkgPath == "" {
+			continue // root package
+		}
+
+		if pkg.Integrity == "" {
+			if pkg.Resolved != "" {
+				resp.Finding(
+					"ARTINT-003",
+					sdk.SeverityCritical,
+					sdk.ConfidenceHigh,
+					fmt.Sprintf("Lockfile entry missing integrity hash: %s@%s", pkgPath, pkg.Version),
+				).
+					At(filePath, 0, 0).
+					WithMetadata("package", pkgPath).
+					WithMetadata("version", pkg.Version).
+					WithMetadata("type", "missing_integrity").
+					Done()
+			}
+			continue
+		}
+
+		checkSRIEntries(resp, filePath, pkgPath, pkg.Version, pkg.Integrity, func() (string, bool) {
+			return findArtifactByBasename(artifactDirs, tarballBasename(pkg.Resolved))
+		})
+	}
+}
+
+// tarballBasename extracts the filename npm would cache a resolved tarball
+// URL under, e.g. "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz"
+// -> "lodash-4.17.21.tgz".
+func tarballBasename(resolved string) string {
+	return path.Base(strings.TrimSuffix(resolved, "/"))
+}
+
+// checkSRIEntries validates every whitespace-separated SRI entry in value
+// (npm allows multiple hashes for the same resource) and, if locateTarball
+// finds the tarball on disk, recomputes and compares its digest.
+// Malformed or weak entries are reported as ARTINT-005; digest mismatches
+// against a located tarball are reported as ARTINT-004.
+func checkSRIEntries(resp *sdk.ResponseBuilder, filePath, pkgPath, version, value string, locateTarball func() (string, bool)) {
+	for _, entry := range strings.Fields(value) {
+		digest, err := parseSRI(entry)
+		if err != nil {
+			resp.Finding(
+				"ARTINT-005",
+				sdk.SeverityMedium,
+				sdk.ConfidenceHigh,
+				fmt.Sprintf("Invalid integrity entry for %s@%s: %v", pkgPath, version, err),
+			).
+				At(filePath, 0, 0).
+				WithMetadata("package", pkgPath).
+				WithMetadata("version", version).
+				WithMetadata("type", "invalid_integrity").
+				Done()
+			continue
+		}
+
+		tarballPath, ok := locateTarball()
+		if !ok {
+			continue
+		}
+
+		tarballData, err := os.ReadFile(tarballPath)
+		if err != nil {
+			continue
+		}
+
+		actual, matches := verifySRI(digest, tarballData)
+		if matches {
+			continue
+		}
+
+		resp.Finding(
+			"ARTINT-004",
+			sdk.SeverityCritical,
+			sdk.ConfidenceHigh,
+			fmt.Sprintf("SRI mismatch for %s@%s: declared %s does not match on-disk tarball", pkgPath, version, digest.Algorithm),
+		).
+			At(filePath, 0, 0).
+			WithMetadata("package", pkgPath).
+			WithMetadata("version", version).
+			WithMetadata("algorithm", digest.Algorithm).
+			WithMetadata("expected", entry).
+			WithMetadata("actual", digest.Algorithm+"-"+actual).
+			WithMetadata("type", "sri_mismatch").
+			Done()
+	}
+}
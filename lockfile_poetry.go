@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// rePoetryName matches a poetry.lock `[[package]]` block's `name = "..."` line.
+var rePoetryName = regexp.MustCompile(`^name\s*=\s*"([^"]+)"`)
+
+// rePoetryVersion matches a poetry.lock `[[package]]` block's `version = "..."` line.
+var rePoetryVersion = regexp.MustCompile(`^version\s*=\s*"([^"]+)"`)
+
+// rePoetryFileHash matches a `{file = "...", hash = "sha256:..."}` entry
+// inside a package's `files = [...]` array.
+var rePoetryFileHash = regexp.MustCompile(`hash\s*=\s*"([^"]+)"`)
+
+// checkPoetryLockIntegrity validates the `files = [...]` hash entries in
+// each poetry.lock `[[package]]` block.
+func checkPoetryLockIntegrity(resp *sdk.ResponseBuilder, filePath string) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	var name, version string
+	fileCount := 0
+
+	flush := func() {
+		if name != "" && fileCount == 0 {
+			resp.Finding(
+				"ARTINT-003",
+				sdk.SeverityCritical,
+				sdk.ConfidenceHigh,
+				fmt.Sprintf("poetry.lock entry has no file hashes: %s@%s", name, version),
+			).
+				At(filePath, 0, 0).
+				WithMetadata("package", name).
+				WithMetadata("version", version).
+				WithMetadata("type", "missing_integrity").
+				Done()
+		}
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "[[package]]" {
+			flush()
+			name, version = "", ""
+			fileCount = 0
+			continue
+		}
+
+		if m := rePoetryName.FindStringSubmatch(line); m != nil {
+			name = m[1]
+			continue
+		}
+		if m := rePoetryVersion.FindStringSubmatch(line); m != nil {
+			version = m[1]
+			continue
+		}
+		if m := rePoetryFileHash.FindStringSubmatch(line); m != nil {
+			fileCount++
+			if !strings.HasPrefix(m[1], "sha256:") || len(m[1]) != len("sha256:")+64 || !isHex(m[1][len("sha256:"):]) {
+				resp.Finding(
+					"ARTINT-005",
+					sdk.SeverityMedium,
+					sdk.ConfidenceHigh,
+					fmt.Sprintf("Malformed poetry.lock file hash for %s@%s", name, version),
+				).
+					At(filePath, 0, 0).
+					WithMetadata("package", name).
+					WithMetadata("version", version).
+					WithMetadata("type", "invalid_integrity").
+					Done()
+			}
+		}
+	}
+	flush()
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+func TestParseSRI(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   string
+		wantErr bool
+	}{
+		{"valid sha512", "sha512-XI5MPzVNApkWlbEhpzsUUVqIRXb33YMulEYm0pNfMR2sqSSw9jOhmKfVb7r+EYvP2Rhr0JsR0GQ6RnLqlfOa/A==", false},
+		{"valid sha256", "sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", false},
+		{"weak sha1", "sha1-2jmj7l5rSw0yVb/vlWAYkK/YBwk=", true},
+		{"weak md5", "md5-1B2M2Y8AsgTpgAmY7PhCfg==", true},
+		{"unknown algorithm", "crc32-AAAAAA==", true},
+		{"malformed base64", "sha256-not-valid-base64!!!", true},
+		{"no separator", "sha256onlynodash", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSRI(tt.entry)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSRI(%q) error = %v, wantErr %v", tt.entry, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifySRI(t *testing.T) {
+	digest, err := parseSRI("sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=")
+	if err != nil {
+		t.Fatalf("parseSRI: %v", err)
+	}
+
+	if _, matches := verifySRI(digest, []byte("")); !matches {
+		t.Error("expected sha256 of empty string to match")
+	}
+	if _, matches := verifySRI(digest, []byte("not empty")); matches {
+		t.Error("expected mismatch for non-empty content")
+	}
+}
+
+func TestLockfileParsers(t *testing.T) {
+	tests := []struct {
+		name         string
+		fixture      string
+		check        func(resp *sdk.ResponseBuilder, path string)
+		wantRuleIDs  []string
+		wantNoRuleID string
+	}{
+		{
+			name:        "yarn v1 missing integrity",
+			fixture:     "yarn-missing.lock",
+			check:       func(resp *sdk.ResponseBuilder, path string) { checkYarnLockIntegrity(resp, path, nil) },
+			wantRuleIDs: []string{"ARTINT-003"},
+		},
+		{
+			name:         "yarn v1 valid integrity",
+			fixture:      "yarn-valid.lock",
+			check:        func(resp *sdk.ResponseBuilder, path string) { checkYarnLockIntegrity(resp, path, nil) },
+			wantNoRuleID: "ARTINT-005",
+		},
+		{
+			name:        "cargo registry package without checksum",
+			fixture:     "cargo-mixed.lock",
+			check:       func(resp *sdk.ResponseBuilder, path string) { checkCargoLockIntegrity(resp, path) },
+			wantRuleIDs: []string{"ARTINT-003"},
+		},
+		{
+			name:        "poetry package without file hashes",
+			fixture:     "poetry-mixed.lock",
+			check:       func(resp *sdk.ResponseBuilder, path string) { checkPoetryLockIntegrity(resp, path) },
+			wantRuleIDs: []string{"ARTINT-003"},
+		},
+		{
+			name:        "composer package without shasum",
+			fixture:     "composer-mixed.json",
+			check:       func(resp *sdk.ResponseBuilder, path string) { checkComposerLockIntegrity(resp, path) },
+			wantRuleIDs: []string{"ARTINT-003"},
+		},
+		{
+			name:        "pnpm package without integrity",
+			fixture:     "pnpm-mixed.yaml",
+			check:       func(resp *sdk.ResponseBuilder, path string) { checkPNPMLockIntegrity(resp, path) },
+			wantRuleIDs: []string{"ARTINT-003"},
+		},
+		{
+			name:        "bundler checksums section with malformed entry",
+			fixture:     "Gemfile.lock",
+			check:       func(resp *sdk.ResponseBuilder, path string) { checkGemfileLockIntegrity(resp, path) },
+			wantRuleIDs: []string{"ARTINT-005"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := sdk.NewResponse()
+			tt.check(resp, filepath.Join("testdata", "lockfiles", tt.fixture))
+			findings := resp.Build().GetFindings()
+
+			for _, ruleID := range tt.wantRuleIDs {
+				if len(findByRule(findings, ruleID)) == 0 {
+					t.Errorf("expected at least one %s finding", ruleID)
+				}
+			}
+			if tt.wantNoRuleID != "" && len(findByRule(findings, tt.wantNoRuleID)) != 0 {
+				t.Errorf("expected no %s findings", tt.wantNoRuleID)
+			}
+		})
+	}
+}
@@ -1,20 +1,20 @@
 package main
 
 import (
-	"bufio"
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
 	"github.com/nox-hq/nox/sdk"
+
+	"github.com/nox-hq/nox-plugin-artifact-integrity/internal/attest"
 )
 
 var version = "dev"
@@ -36,6 +36,17 @@ var releaseArtifactExtensions = map[string]bool{
 	".apk":     true,
 }
 
+// libraryArtifactExtensions is the subset of releaseArtifactExtensions that
+// package managers install as a dependency rather than run directly --
+// used to classify components in the generated CycloneDX manifest as
+// "library" instead of the generic "file".
+var libraryArtifactExtensions = map[string]bool{
+	".whl": true,
+	".gem": true,
+	".jar": true,
+	".war": true,
+}
+
 // signatureExtensions lists extensions used for artifact signatures.
 var signatureExtensions = map[string]bool{
 	".sig":     true,
@@ -45,6 +56,16 @@ var signatureExtensions = map[string]bool{
 	".minisig": true,
 }
 
+// attestationExtensions lists extensions used for Sigstore bundles and
+// in-toto/DSSE provenance attestations.
+var attestationExtensions = map[string]bool{
+	".sigstore":      true,
+	".bundle.json":   true,
+	".cosign.bundle": true,
+	".intoto.jsonl":  true,
+	".att":           true,
+}
+
 // checksumExtensions lists extensions used for checksum files.
 var checksumExtensions = map[string]bool{
 	".sha256":    true,
@@ -57,17 +78,20 @@ var checksumExtensions = map[string]bool{
 
 // checksumFileNames lists common names for checksum manifest files.
 var checksumFileNames = map[string]bool{
-	"SHA256SUMS":     true,
-	"SHA512SUMS":     true,
-	"CHECKSUMS":      true,
-	"checksums.txt":  true,
-	"CHECKSUMS.txt":  true,
+	"SHA256SUMS":    true,
+	"SHA512SUMS":    true,
+	"MD5SUMS":       true,
+	"SHA1SUMS":      true,
+	"CHECKSUMS":     true,
+	"checksums.txt": true,
+	"CHECKSUMS.txt": true,
 }
 
 // lockfileNames lists lockfile names that contain checksums to verify.
 var lockfileNames = map[string]bool{
 	"package-lock.json": true,
 	"yarn.lock":         true,
+	"pnpm-lock.yaml":    true,
 	"go.sum":            true,
 	"Gemfile.lock":      true,
 	"poetry.lock":       true,
@@ -75,9 +99,6 @@ var lockfileNames = map[string]bool{
 	"composer.lock":     true,
 }
 
-// reChecksumLine matches a hex checksum followed by a filename in checksum files.
-var reChecksumLine = regexp.MustCompile(`^([a-fA-F0-9]{32,128})\s+(.+)$`)
-
 // skippedDirs contains directory names to skip during recursive walks.
 var skippedDirs = map[string]bool{
 	".git":         true,
@@ -91,12 +112,14 @@ func buildServer() *sdk.PluginServer {
 	manifest := sdk.NewManifest("nox/artifact-integrity", version).
 		Capability("artifact-integrity", "Release verification, build comparison, and artifact signing detection").
 		Tool("scan", "Scan for missing checksums, unsigned artifacts, and checksum mismatches", true).
+		Tool("compare", "Diff two artifact trees for reproducible-build mismatches", true).
 		Done().
 		Safety(sdk.WithRiskClass(sdk.RiskPassive)).
 		Build()
 
 	return sdk.NewPluginServer(manifest).
-		HandleTool("scan", handleScan)
+		HandleTool("scan", handleScan).
+		HandleTool("compare", handleCompare)
 }
 
 func handleScan(ctx context.Context, req sdk.ToolRequest) (*pluginv1.InvokeToolResponse, error) {
@@ -111,6 +134,10 @@ func handleScan(ctx context.Context, req sdk.ToolRequest) (*pluginv1.InvokeToolR
 		return resp.Build(), nil
 	}
 
+	attestOpts := attestOptionsFromInput(req.Input)
+	artifactDirs := artifactDirsFromInput(req.Input, workspaceRoot)
+	sumdbOpts := sumdbOptionsFromInput(req.Input)
+
 	// Collect all files first for cross-referencing.
 	var allFiles []string
 	fileSet := make(map[string]bool)
@@ -149,19 +176,29 @@ func handleScan(ctx context.Context, req sdk.ToolRequest) (*pluginv1.InvokeToolR
 		if isReleaseArtifact(name) {
 			checkMissingChecksum(resp, path, name, dir, fileSet)
 			checkUnsignedArtifact(resp, path, name, dir, fileSet)
+			checkAttestation(resp, path, name, dir, fileSet, attestOpts)
 		}
 
 		// Check checksum files for mismatches.
-		if checksumFileNames[name] {
+		if isChecksumManifest(name) {
 			checkChecksumMismatches(resp, path, dir)
 		}
 
 		// Check lockfiles for integrity issues.
 		if lockfileNames[name] {
-			checkLockfileIntegrity(resp, path)
+			checkLockfileIntegrity(ctx, resp, path, artifactDirs, sumdbOpts)
+		}
+
+		// Verify hashes recorded in any CycloneDX/SPDX SBOM present.
+		if isSBOMFile(name) {
+			checkSBOMFile(resp, workspaceRoot, path, name, fileSet)
 		}
 	}
 
+	if outputFormat, _ := req.Input["output_format"].(string); outputFormat == "cyclonedx" {
+		emitCycloneDXOutput(resp, workspaceRoot, allFiles, fileSet)
+	}
+
 	return resp.Build(), nil
 }
 
@@ -176,6 +213,19 @@ func isReleaseArtifact(name string) bool {
 	return false
 }
 
+// isLibraryArtifact checks whether a filename has an extension package
+// managers install as a dependency (a wheel, gem, or JAR/WAR), as opposed
+// to a generic release archive or installer.
+func isLibraryArtifact(name string) bool {
+	lower := strings.ToLower(name)
+	for ext := range libraryArtifactExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
 // hasCompanionFile checks if a file with any of the given extensions exists
 // alongside the artifact.
 func hasCompanionFile(name, dir string, fileSet map[string]bool, extensions map[string]bool) bool {
@@ -188,6 +238,18 @@ func hasCompanionFile(name, dir string, fileSet map[string]bool, extensions map[
 	return false
 }
 
+// findCompanionFile is like hasCompanionFile but returns the matching
+// companion's path, for callers that need to read it.
+func findCompanionFile(name, dir string, fileSet map[string]bool, extensions map[string]bool) (string, bool) {
+	for ext := range extensions {
+		companion := filepath.Join(dir, name+ext)
+		if fileSet[companion] {
+			return companion, true
+		}
+	}
+	return "", false
+}
+
 // checkMissingChecksum reports when a release artifact has no corresponding checksum file.
 func checkMissingChecksum(resp *sdk.ResponseBuilder, path, name, dir string, fileSet map[string]bool) {
 	if hasCompanionFile(name, dir, fileSet, checksumExtensions) {
@@ -231,161 +293,169 @@ func checkUnsignedArtifact(resp *sdk.ResponseBuilder, path, name, dir string, fi
 		Done()
 }
 
-// checkChecksumMismatches reads a checksum manifest file and verifies that
-// referenced files match their declared checksums.
-func checkChecksumMismatches(resp *sdk.ResponseBuilder, checksumFilePath, dir string) {
-	f, err := os.Open(checksumFilePath)
-	if err != nil {
-		return
-	}
-	defer f.Close()
-
-	scanner := bufio.NewScanner(f)
-	lineNum := 0
-	for scanner.Scan() {
-		lineNum++
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
+// attestOptionsFromInput builds attestation trust configuration from tool
+// input, so callers can supply the Fulcio roots and allowed signer
+// identities their organization trusts. Both are optional: with neither
+// set, checkAttestation still verifies subject digests and predicate
+// types, it just can't confirm who signed.
+func attestOptionsFromInput(input map[string]any) attest.Options {
+	var opts attest.Options
+
+	if pemBlob, ok := input["trusted_fulcio_roots"].(string); ok && pemBlob != "" {
+		pool := x509.NewCertPool()
+		if pool.AppendCertsFromPEM([]byte(pemBlob)) {
+			opts.Roots = pool
 		}
+	}
 
-		matches := reChecksumLine.FindStringSubmatch(line)
-		if len(matches) != 3 {
-			continue
+	if rawIdentities, ok := input["allowed_identities"].([]any); ok {
+		for _, raw := range rawIdentities {
+			m, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			san, _ := m["san"].(string)
+			issuer, _ := m["issuer"].(string)
+			if san == "" {
+				continue
+			}
+			opts.Allowed = append(opts.Allowed, attest.Identity{SAN: san, Issuer: issuer})
 		}
+	}
+
+	return opts
+}
 
-		declaredHash := strings.ToLower(matches[1])
-		referencedFile := strings.TrimSpace(matches[2])
-		// Handle BSD-style prefix.
-		referencedFile = strings.TrimPrefix(referencedFile, "*")
+// artifactDirsFromInput resolves the `artifact_dirs` tool input (a list of
+// workspace-relative directories that may hold offline tarball mirrors or
+// package manager caches) to absolute paths, plus the default cache
+// locations lockfile checkers look in regardless of configuration.
+func artifactDirsFromInput(input map[string]any, workspaceRoot string) []string {
+	dirs := []string{
+		filepath.Join(workspaceRoot, "node_modules", ".cache"),
+	}
 
-		targetPath := filepath.Join(dir, referencedFile)
-		data, err := os.ReadFile(targetPath)
-		if err != nil {
-			// File not found -- not a mismatch, just missing.
+	rawDirs, _ := input["artifact_dirs"].([]any)
+	for _, raw := range rawDirs {
+		dir, ok := raw.(string)
+		if !ok || dir == "" {
 			continue
 		}
-
-		// Compute SHA-256 (most common for 64-char hashes).
-		if len(declaredHash) == 64 {
-			h := sha256.Sum256(data)
-			actualHash := hex.EncodeToString(h[:])
-			if actualHash != declaredHash {
-				resp.Finding(
-					"ARTINT-003",
-					sdk.SeverityCritical,
-					sdk.ConfidenceHigh,
-					fmt.Sprintf("Checksum mismatch for %s: declared=%s actual=%s", referencedFile, declaredHash[:16]+"...", actualHash[:16]+"..."),
-				).
-					At(checksumFilePath, lineNum, lineNum).
-					WithMetadata("file", referencedFile).
-					WithMetadata("type", "checksum_mismatch").
-					Done()
-			}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(workspaceRoot, dir)
 		}
+		dirs = append(dirs, dir)
 	}
-}
 
-// npmLockfile represents a minimal package-lock.json structure.
-type npmLockfile struct {
-	Packages map[string]struct {
-		Version   string `json:"version"`
-		Resolved  string `json:"resolved"`
-		Integrity string `json:"integrity"`
-	} `json:"packages"`
+	return dirs
 }
 
-// checkLockfileIntegrity inspects lockfiles for missing or inconsistent
-// integrity metadata.
-func checkLockfileIntegrity(resp *sdk.ResponseBuilder, filePath string) {
-	name := filepath.Base(filePath)
-
-	switch name {
-	case "package-lock.json":
-		checkNPMLockfileIntegrity(resp, filePath)
-	case "go.sum":
-		checkGoSumIntegrity(resp, filePath)
+// findArtifactByBasename searches dirs for a file with the given basename,
+// returning its path if present on disk.
+func findArtifactByBasename(dirs []string, basename string) (string, bool) {
+	for _, dir := range dirs {
+		candidate := filepath.Join(dir, basename)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, true
+		}
 	}
+	return "", false
 }
 
-// checkNPMLockfileIntegrity checks package-lock.json for missing integrity hashes.
-func checkNPMLockfileIntegrity(resp *sdk.ResponseBuilder, filePath string) {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
+// checkAttestation looks for a Sigstore bundle or in-toto/DSSE attestation
+// alongside a release artifact and, when found, verifies that its subject
+// digest matches the on-disk artifact, that its predicate is a recognized
+// SLSA provenance type, and (when trust configuration is supplied) that
+// its signer is one the caller allows.
+func checkAttestation(resp *sdk.ResponseBuilder, path, name, dir string, fileSet map[string]bool, opts attest.Options) {
+	attestationPath, ok := findCompanionFile(name, dir, fileSet, attestationExtensions)
+	if !ok {
 		return
 	}
 
-	var lockfile npmLockfile
-	if err := json.Unmarshal(data, &lockfile); err != nil {
+	artifactData, err := os.ReadFile(path)
+	if err != nil {
 		return
 	}
+	artifactSum := sha256.Sum256(artifactData)
+	artifactDigest := hex.EncodeToString(artifactSum[:])
 
-	for pkgPath, pkg := range lockfile.Packages {
-		if pkgPath == "" {
-			continue // root package
-		}
-		if pkg.Integrity == "" && pkg.Resolved != "" {
-			resp.Finding(
-				"ARTINT-003",
-				sdk.SeverityCritical,
-				sdk.ConfidenceHigh,
-				fmt.Sprintf("Lockfile entry missing integrity hash: %s@%s", pkgPath, pkg.Version),
-			).
-				At(filePath, 0, 0).
-				WithMetadata("package", pkgPath).
-				WithMetadata("version", pkg.Version).
-				WithMetadata("type", "missing_integrity").
-				Done()
-		}
+	bundleData, err := os.ReadFile(attestationPath)
+	if err != nil {
+		return
 	}
-}
-
-// reGoSumLine matches go.sum lines: module version hash.
-var reGoSumLine = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(h1:\S+)$`)
 
-// checkGoSumIntegrity validates the format of go.sum entries.
-func checkGoSumIntegrity(resp *sdk.ResponseBuilder, filePath string) {
-	f, err := os.Open(filePath)
+	result, err := attest.VerifyBundle(bundleData, artifactDigest, opts)
 	if err != nil {
+		// Not a bundle we understand -- nothing to report, but don't
+		// treat a parse failure of a present attestation as silent success.
 		return
 	}
-	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
-	lineNum := 0
-	seen := make(map[string]string) // module@version -> hash
+	if !result.DigestMatches {
+		resp.Finding(
+			"ARTINT-010",
+			sdk.SeverityCritical,
+			sdk.ConfidenceHigh,
+			fmt.Sprintf("Attestation subject digest does not match artifact %s", name),
+		).
+			At(attestationPath, 0, 0).
+			WithMetadata("artifact", name).
+			WithMetadata("type", "subject_digest_mismatch").
+			Done()
+	}
 
-	for scanner.Scan() {
-		lineNum++
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
+	if result.SignerErr != nil && len(opts.Allowed) > 0 {
+		resp.Finding(
+			"ARTINT-011",
+			sdk.SeverityHigh,
+			sdk.ConfidenceHigh,
+			fmt.Sprintf("Attestation for %s signed by an untrusted identity: %v", name, result.SignerErr),
+		).
+			At(attestationPath, 0, 0).
+			WithMetadata("artifact", name).
+			WithMetadata("type", "untrusted_signer").
+			Done()
+	}
 
-		matches := reGoSumLine.FindStringSubmatch(line)
-		if len(matches) != 4 {
-			continue
-		}
+	if !result.KnownPredicate || result.SLSALevel < 2 {
+		resp.Finding(
+			"ARTINT-012",
+			sdk.SeverityMedium,
+			sdk.ConfidenceMedium,
+			fmt.Sprintf("Attestation for %s has missing or weak SLSA provenance (level %d)", name, result.SLSALevel),
+		).
+			At(attestationPath, 0, 0).
+			WithMetadata("artifact", name).
+			WithMetadata("slsa_level", fmt.Sprintf("%d", result.SLSALevel)).
+			WithMetadata("type", "weak_slsa_level").
+			Done()
+	}
+}
 
-		module := matches[1]
-		ver := matches[2]
-		hash := matches[3]
-		key := module + "@" + ver
-
-		if existing, ok := seen[key]; ok && existing != hash {
-			resp.Finding(
-				"ARTINT-003",
-				sdk.SeverityCritical,
-				sdk.ConfidenceHigh,
-				fmt.Sprintf("Duplicate go.sum entry with different hash for %s", key),
-			).
-				At(filePath, lineNum, lineNum).
-				WithMetadata("module", module).
-				WithMetadata("version", ver).
-				WithMetadata("type", "checksum_mismatch").
-				Done()
-		}
-		seen[key] = hash
+// checkLockfileIntegrity inspects lockfiles for missing or inconsistent
+// integrity metadata, recomputing checksums against on-disk artifacts
+// where a matching one can be found under artifactDirs.
+func checkLockfileIntegrity(ctx context.Context, resp *sdk.ResponseBuilder, filePath string, artifactDirs []string, sumdbOpts sumdbOptions) {
+	name := filepath.Base(filePath)
+
+	switch name {
+	case "package-lock.json":
+		checkNPMLockfileIntegrity(resp, filePath, artifactDirs)
+	case "yarn.lock":
+		checkYarnLockIntegrity(resp, filePath, artifactDirs)
+	case "pnpm-lock.yaml":
+		checkPNPMLockIntegrity(resp, filePath)
+	case "Cargo.lock":
+		checkCargoLockIntegrity(resp, filePath)
+	case "Gemfile.lock":
+		checkGemfileLockIntegrity(resp, filePath)
+	case "composer.lock":
+		checkComposerLockIntegrity(resp, filePath)
+	case "poetry.lock":
+		checkPoetryLockIntegrity(resp, filePath)
+	case "go.sum":
+		checkGoSumIntegrity(ctx, resp, filePath, sumdbOpts)
 	}
 }
 
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// isSBOMFile reports whether name looks like a CycloneDX or SPDX SBOM
+// document this scanner knows how to consume.
+func isSBOMFile(name string) bool {
+	lower := strings.ToLower(name)
+	return lower == "bom.json" || strings.HasSuffix(lower, ".cdx.json") || strings.HasSuffix(lower, ".spdx.json")
+}
+
+// cycloneDXHash is one entry in a CycloneDX component's `hashes` array.
+type cycloneDXHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+// cycloneDXComponent is the subset of a CycloneDX component this scanner
+// reads: enough to locate the file it describes and verify its hash.
+type cycloneDXComponent struct {
+	Name    string          `json:"name"`
+	Version string          `json:"version"`
+	Hashes  []cycloneDXHash `json:"hashes"`
+}
+
+// cycloneDXDocument is the subset of a CycloneDX BOM document this
+// scanner consumes.
+type cycloneDXDocument struct {
+	BomFormat  string               `json:"bomFormat"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+// spdxChecksum is one entry in an SPDX file's `checksums` array.
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+// spdxFile is the subset of an SPDX `files` entry this scanner reads.
+type spdxFile struct {
+	FileName  string         `json:"fileName"`
+	Checksums []spdxChecksum `json:"checksums"`
+}
+
+// spdxDocument is the subset of an SPDX document this scanner consumes.
+type spdxDocument struct {
+	SPDXVersion string     `json:"spdxVersion"`
+	Files       []spdxFile `json:"files"`
+}
+
+// checkSBOMFile consumes a CycloneDX or SPDX document found in the
+// workspace and verifies each referenced file's hash against the
+// document's claim, emitting ARTINT-030 on mismatch and ARTINT-031 when a
+// listed component/file carries no hash at all.
+func checkSBOMFile(resp *sdk.ResponseBuilder, workspaceRoot, path, name string, fileSet map[string]bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	if strings.HasSuffix(strings.ToLower(name), ".spdx.json") {
+		checkSPDXDocument(resp, workspaceRoot, path, data, fileSet)
+		return
+	}
+
+	checkCycloneDXDocument(resp, workspaceRoot, path, data, fileSet)
+}
+
+func checkCycloneDXDocument(resp *sdk.ResponseBuilder, workspaceRoot, path string, data []byte, fileSet map[string]bool) {
+	var doc cycloneDXDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return
+	}
+
+	for _, component := range doc.Components {
+		componentPath := filepath.Join(workspaceRoot, component.Name)
+		if !fileSet[componentPath] {
+			continue
+		}
+
+		if len(component.Hashes) == 0 {
+			resp.Finding(
+				"ARTINT-031",
+				sdk.SeverityMedium,
+				sdk.ConfidenceHigh,
+				fmt.Sprintf("SBOM component %s has no recorded hash", component.Name),
+			).
+				At(path, 0, 0).
+				WithMetadata("component", component.Name).
+				WithMetadata("type", "sbom_missing_hash").
+				Done()
+			continue
+		}
+
+		fileData, err := os.ReadFile(componentPath)
+		if err != nil {
+			continue
+		}
+
+		for _, h := range component.Hashes {
+			actual, ok := computeNamedHash(h.Alg, fileData)
+			if !ok {
+				continue
+			}
+			if !strings.EqualFold(actual, h.Content) {
+				resp.Finding(
+					"ARTINT-030",
+					sdk.SeverityCritical,
+					sdk.ConfidenceHigh,
+					fmt.Sprintf("SBOM hash mismatch for component %s", component.Name),
+				).
+					At(path, 0, 0).
+					WithMetadata("component", component.Name).
+					WithMetadata("algorithm", h.Alg).
+					WithMetadata("expected", h.Content).
+					WithMetadata("actual", actual).
+					WithMetadata("type", "sbom_hash_mismatch").
+					Done()
+			}
+		}
+	}
+}
+
+func checkSPDXDocument(resp *sdk.ResponseBuilder, workspaceRoot, path string, data []byte, fileSet map[string]bool) {
+	var doc spdxDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return
+	}
+
+	for _, file := range doc.Files {
+		filePath := filepath.Join(workspaceRoot, strings.TrimPrefix(file.FileName, "./"))
+		if !fileSet[filePath] {
+			continue
+		}
+
+		if len(file.Checksums) == 0 {
+			resp.Finding(
+				"ARTINT-031",
+				sdk.SeverityMedium,
+				sdk.ConfidenceHigh,
+				fmt.Sprintf("SBOM file %s has no recorded checksum", file.FileName),
+			).
+				At(path, 0, 0).
+				WithMetadata("component", file.FileName).
+				WithMetadata("type", "sbom_missing_hash").
+				Done()
+			continue
+		}
+
+		fileData, err := os.ReadFile(filePath)
+		if err != nil {
+			continue
+		}
+
+		for _, c := range file.Checksums {
+			actual, ok := computeNamedHash(c.Algorithm, fileData)
+			if !ok {
+				continue
+			}
+			if !strings.EqualFold(actual, c.ChecksumValue) {
+				resp.Finding(
+					"ARTINT-030",
+					sdk.SeverityCritical,
+					sdk.ConfidenceHigh,
+					fmt.Sprintf("SBOM checksum mismatch for file %s", file.FileName),
+				).
+					At(path, 0, 0).
+					WithMetadata("component", file.FileName).
+					WithMetadata("algorithm", c.Algorithm).
+					WithMetadata("expected", c.ChecksumValue).
+					WithMetadata("actual", actual).
+					WithMetadata("type", "sbom_hash_mismatch").
+					Done()
+			}
+		}
+	}
+}
+
+// computeNamedHash computes data's digest for the hash algorithm named by
+// a CycloneDX `alg` (e.g. "SHA-256") or SPDX `algorithm` (e.g. "SHA256")
+// value, returning ok=false for algorithms this scanner doesn't verify.
+func computeNamedHash(name string, data []byte) (string, bool) {
+	switch strings.ToUpper(strings.ReplaceAll(name, "-", "")) {
+	case "SHA256":
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:]), true
+	case "SHA384":
+		sum := sha512.Sum384(data)
+		return hex.EncodeToString(sum[:]), true
+	case "SHA512":
+		sum := sha512.Sum512(data)
+		return hex.EncodeToString(sum[:]), true
+	default:
+		return "", false
+	}
+}
+
+// generatedCycloneDXHash is a hash entry in the generator output, using
+// CycloneDX's "sha-256"/"sha-512" alg naming.
+type generatedCycloneDXHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+// generatedCycloneDXComponent is one release artifact in the generated
+// CycloneDX document.
+type generatedCycloneDXComponent struct {
+	Type        string                   `json:"type"`
+	Name        string                   `json:"name"`
+	Hashes      []generatedCycloneDXHash `json:"hashes"`
+	Attestation string                   `json:"attestation,omitempty"`
+}
+
+// generatedCycloneDXDocument is the minimal CycloneDX 1.5 document this
+// scanner can produce from its own findings: a manifest of every release
+// artifact discovered, for downstream Nox plugins to consume.
+type generatedCycloneDXDocument struct {
+	BomFormat   string                        `json:"bomFormat"`
+	SpecVersion string                        `json:"specVersion"`
+	Version     int                           `json:"version"`
+	Components  []generatedCycloneDXComponent `json:"components"`
+}
+
+// generateCycloneDX builds a CycloneDX 1.5 manifest of every release
+// artifact in allFiles, with sha-256/sha-512 hashes and, when present,
+// the attestation/signature companion file that was found for it.
+func generateCycloneDX(allFiles []string, fileSet map[string]bool) *generatedCycloneDXDocument {
+	doc := &generatedCycloneDXDocument{
+		BomFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+	}
+
+	for _, path := range allFiles {
+		name := filepath.Base(path)
+		if !isReleaseArtifact(name) {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		sha256Sum := sha256.Sum256(data)
+		sha512Sum := sha512.Sum512(data)
+
+		componentType := "file"
+		if isLibraryArtifact(name) {
+			componentType = "library"
+		}
+
+		component := generatedCycloneDXComponent{
+			Type: componentType,
+			Name: name,
+			Hashes: []generatedCycloneDXHash{
+				{Alg: "SHA-256", Content: hex.EncodeToString(sha256Sum[:])},
+				{Alg: "SHA-512", Content: hex.EncodeToString(sha512Sum[:])},
+			},
+		}
+
+		dir := filepath.Dir(path)
+		if attestationPath, ok := findCompanionFile(name, dir, fileSet, attestationExtensions); ok {
+			component.Attestation = filepath.Base(attestationPath)
+		} else if sigPath, ok := findCompanionFile(name, dir, fileSet, signatureExtensions); ok {
+			component.Attestation = filepath.Base(sigPath)
+		}
+
+		doc.Components = append(doc.Components, component)
+	}
+
+	return doc
+}
+
+// emitCycloneDXOutput generates a CycloneDX manifest of every release
+// artifact found and attaches it to resp as a low-severity informational
+// finding, so downstream Nox plugins consuming scan output can pick the
+// manifest up from its metadata without a separate request.
+func emitCycloneDXOutput(resp *sdk.ResponseBuilder, workspaceRoot string, allFiles []string, fileSet map[string]bool) {
+	doc := generateCycloneDX(allFiles, fileSet)
+
+	encoded, err := encodeCycloneDX(doc)
+	if err != nil {
+		return
+	}
+
+	resp.Finding(
+		"ARTINT-032",
+		sdk.SeverityLow,
+		sdk.ConfidenceHigh,
+		fmt.Sprintf("Generated CycloneDX SBOM for %d release artifact(s)", len(doc.Components)),
+	).
+		At(workspaceRoot, 0, 0).
+		WithMetadata("cyclonedx", encoded).
+		WithMetadata("component_count", fmt.Sprintf("%d", len(doc.Components))).
+		WithMetadata("type", "sbom_generated").
+		Done()
+}
+
+// encodeCycloneDX renders doc as compact JSON, for attaching to the scan
+// response as an artifact-style metadata blob.
+func encodeCycloneDX(doc *generatedCycloneDXDocument) (string, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
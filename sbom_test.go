@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestScanCycloneDXMismatchAndMissingHash(t *testing.T) {
+	client := testClient(t)
+	resp := invokeScan(t, client, filepath.Join(testdataDir(t), "sbom-cyclonedx"))
+
+	if len(findByRule(resp.GetFindings(), "ARTINT-030")) == 0 {
+		t.Error("expected ARTINT-030 for app.tar.gz hash mismatch")
+	}
+	if len(findByRule(resp.GetFindings(), "ARTINT-031")) == 0 {
+		t.Error("expected ARTINT-031 for unhashed.tar.gz missing hash")
+	}
+}
+
+func TestScanSPDXMismatch(t *testing.T) {
+	client := testClient(t)
+	resp := invokeScan(t, client, filepath.Join(testdataDir(t), "sbom-spdx"))
+
+	if len(findByRule(resp.GetFindings(), "ARTINT-030")) == 0 {
+		t.Error("expected ARTINT-030 for app.tar.gz SPDX checksum mismatch")
+	}
+}
+
+func TestScanCycloneDXOutput(t *testing.T) {
+	client := testClient(t)
+
+	input, err := structpb.NewStruct(map[string]any{
+		"workspace_root": filepath.Join(testdataDir(t), "sbom-cyclonedx"),
+		"output_format":  "cyclonedx",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.InvokeTool(context.Background(), &pluginv1.InvokeToolRequest{
+		ToolName: "scan",
+		Input:    input,
+	})
+	if err != nil {
+		t.Fatalf("InvokeTool(scan): %v", err)
+	}
+
+	found := findByRule(resp.GetFindings(), "ARTINT-032")
+	if len(found) != 1 {
+		t.Fatalf("expected exactly one ARTINT-032 finding, got %d", len(found))
+	}
+
+	var doc generatedCycloneDXDocument
+	if err := json.Unmarshal([]byte(found[0].GetMetadata()["cyclonedx"]), &doc); err != nil {
+		t.Fatalf("unmarshalling generated CycloneDX doc: %v", err)
+	}
+	if doc.BomFormat != "CycloneDX" {
+		t.Errorf("expected bomFormat CycloneDX, got %q", doc.BomFormat)
+	}
+	if len(doc.Components) == 0 {
+		t.Error("expected at least one component in generated CycloneDX doc")
+	}
+}
+
+func TestGenerateCycloneDXComponentType(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "app.tar.gz")
+	jarPath := filepath.Join(dir, "app.jar")
+	if err := os.WriteFile(archivePath, []byte("archive"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jarPath, []byte("jar"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	allFiles := []string{archivePath, jarPath}
+	fileSet := map[string]bool{archivePath: true, jarPath: true}
+	doc := generateCycloneDX(allFiles, fileSet)
+
+	types := map[string]string{}
+	for _, c := range doc.Components {
+		types[c.Name] = c.Type
+	}
+	if types["app.tar.gz"] != "file" {
+		t.Errorf("expected app.tar.gz to be classified as file, got %q", types["app.tar.gz"])
+	}
+	if types["app.jar"] != "library" {
+		t.Errorf("expected app.jar to be classified as library, got %q", types["app.jar"])
+	}
+}
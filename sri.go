@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// sriAlgorithms maps a known Subresource Integrity algorithm token to a
+// constructor for a matching hash.Hash. sha1 and md5 are deliberately
+// absent: the SRI spec permits them, but they're unfit for supply-chain
+// integrity and are rejected as weak instead.
+var sriAlgorithms = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// weakSRIAlgorithms are syntactically valid SRI algorithm tokens that are
+// too weak to trust for integrity verification in this context.
+var weakSRIAlgorithms = map[string]bool{
+	"sha1": true,
+	"md5":  true,
+}
+
+// sriDigest is a single parsed `<algorithm>-<base64>` SRI entry.
+type sriDigest struct {
+	Algorithm string
+	Hash      []byte
+}
+
+// parseSRI parses one SRI entry of the form `sha512-<base64>`. npm allows
+// space-separated multiple entries for the same resource; callers split on
+// whitespace before calling this. It rejects unknown algorithms, weak
+// algorithms, and malformed base64 as syntax errors rather than treating
+// them as missing integrity.
+func parseSRI(entry string) (*sriDigest, error) {
+	algorithm, encoded, found := strings.Cut(entry, "-")
+	if !found {
+		return nil, fmt.Errorf("malformed SRI entry %q: expected <algorithm>-<base64>", entry)
+	}
+
+	if weakSRIAlgorithms[algorithm] {
+		return nil, fmt.Errorf("SRI algorithm %q is too weak for supply-chain integrity", algorithm)
+	}
+	if _, ok := sriAlgorithms[algorithm]; !ok {
+		return nil, fmt.Errorf("unknown SRI algorithm %q", algorithm)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("malformed SRI base64 value: %w", err)
+	}
+
+	return &sriDigest{Algorithm: algorithm, Hash: decoded}, nil
+}
+
+// verifySRI computes data's digest with digest.Algorithm and reports
+// whether it matches, along with the base64-encoded actual digest for use
+// in mismatch findings.
+func verifySRI(digest *sriDigest, data []byte) (actual string, matches bool) {
+	h := sriAlgorithms[digest.Algorithm]()
+	h.Write(data)
+	sum := h.Sum(nil)
+	return base64.StdEncoding.EncodeToString(sum), bytes.Equal(sum, digest.Hash)
+}